@@ -0,0 +1,82 @@
+package cmd
+
+import (
+    "fmt"
+    "os"
+    "strings"
+
+    "github.com/fatih/color"
+    "github.com/spf13/cobra"
+)
+
+var (
+    checkDiff      bool     // 输出过期文件的 diff
+    exportFailed   bool     // 生成过程中是否有失败
+    nonInteractive bool     // 缺少参数时不交互询问, 用于 CI
+    missingParams  []string // 非交互时缺少的参数
+)
+
+// checkCmd 在内存中重新生成代码并与已有文件比较, 有文件过期时以非 0 状态退出, 用于 CI
+var checkCmd = &cobra.Command{
+    Use:   "check [database] [tables...]",
+    Short: "regenerate in memory and exit with non-zero status if any generated file is out of date",
+    PreRunE: func(cmd *cobra.Command, args []string) error {
+        renderOnly = true
+        nonInteractive = true
+        if err := prepareExport(args); nil != err {
+            color.Red("Error: %v\n", err)
+            os.Exit(2)
+        }
+        if 0 < len(missingParams) {
+            color.Red("The parameters[%s] are required, please provide them by the config file or flags\n", strings.Join(missingParams, ", "))
+            os.Exit(2)
+        }
+        return nil
+    },
+    Run: func(cmd *cobra.Command, args []string) {
+        if err := exportTables(); nil != err {
+            color.Red("%v\n", err)
+            os.Exit(2)
+        }
+        stale := 0
+        for _, v := range renderedFiles {
+            switch v.Status {
+            case fileCreated:
+                color.Yellow("missing  %s", v.Path)
+            case fileChanged, fileModified:
+                color.Yellow("changed  %s", v.Path)
+            case fileOrphan:
+                color.Yellow("orphan   %s", v.Path)
+            default:
+                continue
+            }
+            stale++
+            if checkDiff && "" != v.Diff {
+                fmt.Print(v.Diff)
+            }
+        }
+        if exportFailed {
+            color.Red("Check failed, some files could not be generated.\n")
+            os.Exit(2)
+        }
+        if 0 < stale {
+            color.Red("%d of %d generated files are out of date, regenerate them with mybatis-export.\n", stale, len(renderedFiles))
+            os.Exit(1)
+        }
+        color.Green("All of %d generated files are up to date.\n", len(renderedFiles))
+    },
+}
+
+// askable 交互询问缺少的参数前调用, 非交互时记录缺少的参数并返回 false
+func askable(name string) bool {
+    if !nonInteractive {
+        return true
+    }
+    missingParams = append(missingParams, name)
+    return false
+}
+
+func init() {
+    rootCmd.AddCommand(checkCmd)
+    checkCmd.Flags().BoolVar(&checkDiff, "diff", false, "print the diff of the out of date files")
+}
@@ -0,0 +1,100 @@
+package cmd
+
+import (
+    "regexp"
+    "strconv"
+    "strings"
+)
+
+// 自定义区域的标记, Java/Kotlin 中为 // @custom-begin name ... // @custom-end,
+// xml 中为 <!-- @custom-begin name --> ... <!-- @custom-end -->, name 可以省略
+var (
+    customBeginRegexp = regexp.MustCompile(`^\s*(?://|<!--)\s*@custom-begin(?:\s+([\w.-]+))?\s*(?:-->)?\s*$`)
+    customEndRegexp   = regexp.MustCompile(`^\s*(?://|<!--)\s*@custom-end\s*(?:-->)?\s*$`)
+)
+
+type customRegion struct {
+    Name    string
+    Content string // 开始和结束标记之间的内容, 不包含标记所在的行
+}
+
+// customRegions 解析内容中的自定义区域, 未命名的区域按出现顺序命名为 #1, #2 ...
+func customRegions(content string) []customRegion {
+    var regions []customRegion
+    var current *customRegion
+    var sb strings.Builder
+    unnamed := 0
+    for _, line := range strings.SplitAfter(content, "\n") {
+        trimmed := strings.TrimRight(line, "\r\n")
+        if nil == current {
+            if m := customBeginRegexp.FindStringSubmatch(trimmed); nil != m {
+                name := m[1]
+                if "" == name {
+                    unnamed++
+                    name = "#" + strconv.Itoa(unnamed)
+                }
+                current = &customRegion{Name: name}
+                sb.Reset()
+            }
+            continue
+        }
+        if customEndRegexp.MatchString(trimmed) {
+            current.Content = sb.String()
+            regions = append(regions, *current)
+            current = nil
+            continue
+        }
+        sb.WriteString(line)
+    }
+    return regions // 没有结束标记的区域忽略
+}
+
+// mergeCustomRegions 将已有文件中自定义区域的内容填充到新生成的内容中,
+// 返回合并后的内容以及新内容中不存在而被丢弃的区域
+func mergeCustomRegions(rendered, existing string) (string, []string) {
+    regions := customRegions(existing)
+    if 0 == len(regions) {
+        return rendered, nil
+    }
+    saved := map[string]string{}
+    for _, v := range regions {
+        saved[v.Name] = v.Content
+    }
+    used := map[string]bool{}
+    var sb strings.Builder
+    lines := strings.SplitAfter(rendered, "\n")
+    unnamed := 0
+    for i := 0; i < len(lines); i++ {
+        sb.WriteString(lines[i])
+        m := customBeginRegexp.FindStringSubmatch(strings.TrimRight(lines[i], "\r\n"))
+        if nil == m {
+            continue
+        }
+        name := m[1]
+        if "" == name {
+            unnamed++
+            name = "#" + strconv.Itoa(unnamed)
+        }
+        content, ok := saved[name]
+        if !ok || used[name] {
+            continue
+        }
+        end := i + 1
+        for end < len(lines) && !customEndRegexp.MatchString(strings.TrimRight(lines[end], "\r\n")) {
+            end++
+        }
+        if end == len(lines) { // 没有结束标记, 保留生成的内容
+            continue
+        }
+        used[name] = true
+        sb.WriteString(content) // 区域内的默认内容使用已有文件中的内容替换
+        i = end - 1
+    }
+    var dropped []string
+    for _, v := range regions {
+        if !used[v.Name] && "" != strings.TrimSpace(v.Content) {
+            dropped = append(dropped, v.Name)
+        }
+    }
+    return sb.String(), dropped
+}
@@ -0,0 +1,153 @@
+package cmd
+
+import (
+    "reflect"
+    "testing"
+)
+
+func TestMergeCustomRegions(t *testing.T) {
+    tests := []struct {
+        name     string
+        rendered string
+        existing string
+        merged   string
+        dropped  []string
+    }{
+        {
+            name: "java named regions",
+            rendered: "package a;\n" +
+                "// @custom-begin imports\n" +
+                "// @custom-end\n" +
+                "public class A {\n" +
+                "    private Long id;\n" +
+                "    // @custom-begin methods\n" +
+                "    // @custom-end\n" +
+                "}\n",
+            existing: "package a;\n" +
+                "// @custom-begin imports\n" +
+                "import java.util.List;\n" +
+                "// @custom-end\n" +
+                "public class A {\n" +
+                "    private Integer id;\n" +
+                "    // @custom-begin methods\n" +
+                "    public List<String> names() {\n" +
+                "        return null;\n" +
+                "    }\n" +
+                "    // @custom-end\n" +
+                "}\n",
+            merged: "package a;\n" +
+                "// @custom-begin imports\n" +
+                "import java.util.List;\n" +
+                "// @custom-end\n" +
+                "public class A {\n" +
+                "    private Long id;\n" +
+                "    // @custom-begin methods\n" +
+                "    public List<String> names() {\n" +
+                "        return null;\n" +
+                "    }\n" +
+                "    // @custom-end\n" +
+                "}\n",
+        },
+        {
+            name: "xml named region",
+            rendered: "<mapper>\n" +
+                "    <select id=\"get\">select 2</select>\n" +
+                "    <!-- @custom-begin statements -->\n" +
+                "    <!-- @custom-end -->\n" +
+                "</mapper>\n",
+            existing: "<mapper>\n" +
+                "    <select id=\"get\">select 1</select>\n" +
+                "    <!--@custom-begin statements-->\n" +
+                "    <select id=\"count\">select count(*)</select>\n" +
+                "    <!--@custom-end-->\n" +
+                "</mapper>\n",
+            merged: "<mapper>\n" +
+                "    <select id=\"get\">select 2</select>\n" +
+                "    <!-- @custom-begin statements -->\n" +
+                "    <select id=\"count\">select count(*)</select>\n" +
+                "    <!-- @custom-end -->\n" +
+                "</mapper>\n",
+        },
+        {
+            name: "regions moved and unnamed regions by order",
+            rendered: "// @custom-begin b\n// @custom-end\n" +
+                "// @custom-begin\n// @custom-end\n" +
+                "// @custom-begin a\n// @custom-end\n",
+            existing: "// @custom-begin a\nA\n// @custom-end\n" +
+                "// @custom-begin\nfirst\n// @custom-end\n" +
+                "// @custom-begin b\nB\n// @custom-end\n" +
+                "// @custom-begin\nsecond\n// @custom-end\n",
+            merged: "// @custom-begin b\nB\n// @custom-end\n" +
+                "// @custom-begin\nfirst\n// @custom-end\n" +
+                "// @custom-begin a\nA\n// @custom-end\n",
+            dropped: []string{"#2"},
+        },
+        {
+            name:     "region no longer defined",
+            rendered: "class A {\n    // @custom-begin methods\n    // @custom-end\n}\n",
+            existing: "class A {\n    // @custom-begin fields\n    int x;\n    // @custom-end\n" +
+                "    // @custom-begin empty\n\n    // @custom-end\n" +
+                "    // @custom-begin methods\n    void m() {}\n    // @custom-end\n}\n",
+            merged:  "class A {\n    // @custom-begin methods\n    void m() {}\n    // @custom-end\n}\n",
+            dropped: []string{"fields"},
+        },
+        {
+            name:     "template default content is kept without existing region",
+            rendered: "// @custom-begin methods\n// default\n// @custom-end\n",
+            existing: "// no regions\n",
+            merged:   "// @custom-begin methods\n// default\n// @custom-end\n",
+        },
+        {
+            name:     "existing region without end marker is ignored",
+            rendered: "// @custom-begin methods\n// @custom-end\n",
+            existing: "// @custom-begin methods\nvoid m() {}\n",
+            merged:   "// @custom-begin methods\n// @custom-end\n",
+        },
+        {
+            name:     "existing end marker without begin is ignored",
+            rendered: "// @custom-begin methods\n// @custom-end\n",
+            existing: "void x() {}\n// @custom-end\n// @custom-begin methods\nvoid m() {}\n// @custom-end\n",
+            merged:   "// @custom-begin methods\nvoid m() {}\n// @custom-end\n",
+        },
+        {
+            name:     "rendered region without end marker keeps the generated content",
+            rendered: "// @custom-begin methods\nvoid generated() {}\n",
+            existing: "// @custom-begin methods\nvoid m() {}\n// @custom-end\n",
+            merged:   "// @custom-begin methods\nvoid generated() {}\n",
+            dropped:  []string{"methods"},
+        },
+        {
+            name:     "duplicated region is filled once",
+            rendered: "// @custom-begin m\n// @custom-end\n// @custom-begin m\n// @custom-end\n",
+            existing: "// @custom-begin m\nvoid m() {}\n// @custom-end\n",
+            merged:   "// @custom-begin m\nvoid m() {}\n// @custom-end\n// @custom-begin m\n// @custom-end\n",
+        },
+        {
+            name:     "crlf line endings",
+            rendered: "// @custom-begin m\r\n// @custom-end\r\n",
+            existing: "// @custom-begin m\r\nvoid m() {}\r\n// @custom-end\r\n",
+            merged:   "// @custom-begin m\r\nvoid m() {}\r\n// @custom-end\r\n",
+        },
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            merged, dropped := mergeCustomRegions(tt.rendered, tt.existing)
+            if merged != tt.merged {
+                t.Errorf("merged:\n%s\nwant:\n%s", merged, tt.merged)
+            }
+            if !reflect.DeepEqual(dropped, tt.dropped) {
+                t.Errorf("dropped = %v, want %v", dropped, tt.dropped)
+            }
+        })
+    }
+}
+
+func TestMergeCustomRegionsRegenerate(t *testing.T) {
+    rendered := "class A {\n    // @custom-begin methods\n    // @custom-end\n}\n"
+    existing := "class A {\n    // @custom-begin methods\n    void m() {}\n    // @custom-end\n}\n"
+    merged, _ := mergeCustomRegions(rendered, existing)
+    again, dropped := mergeCustomRegions(rendered, merged)
+    if again != merged || 0 != len(dropped) {
+        t.Errorf("regenerate changed the content:\n%s\nwant:\n%s", again, merged)
+    }
+}
@@ -0,0 +1,147 @@
+package cmd
+
+import (
+    "fmt"
+    "strings"
+)
+
+// 统一格式 diff 中变更前后保留的上下文行数
+const diffContext = 3
+
+// 追加在没有换行结尾的最后一行之后, 使其与有换行的同一行不相等
+const noNewline = "\x00"
+
+type diffLine struct {
+    Kind byte // ' ' 未变, '-' 删除, '+' 新增
+    Text string
+}
+
+// splitLines 按行拆分, 最后一行没有换行时追加 noNewline 标记
+func splitLines(s string) []string {
+    if "" == s {
+        return nil
+    }
+    lines := strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+    if !strings.HasSuffix(s, "\n") {
+        lines[len(lines)-1] += noNewline
+    }
+    return lines
+}
+
+// diffLines 基于最长公共子序列计算 a 到 b 的逐行差异
+func diffLines(a, b []string) []diffLine {
+    n, m := len(a), len(b)
+    lcs := make([][]int32, n+1)
+    for i := range lcs {
+        lcs[i] = make([]int32, m+1)
+    }
+    for i := n - 1; i >= 0; i-- {
+        for j := m - 1; j >= 0; j-- {
+            if a[i] == b[j] {
+                lcs[i][j] = lcs[i+1][j+1] + 1
+            } else if lcs[i+1][j] >= lcs[i][j+1] {
+                lcs[i][j] = lcs[i+1][j]
+            } else {
+                lcs[i][j] = lcs[i][j+1]
+            }
+        }
+    }
+    var lines []diffLine
+    i, j := 0, 0
+    for i < n && j < m {
+        switch {
+        case a[i] == b[j]:
+            lines = append(lines, diffLine{' ', a[i]})
+            i++
+            j++
+        case lcs[i+1][j] >= lcs[i][j+1]:
+            lines = append(lines, diffLine{'-', a[i]})
+            i++
+        default:
+            lines = append(lines, diffLine{'+', b[j]})
+            j++
+        }
+    }
+    for ; i < n; i++ {
+        lines = append(lines, diffLine{'-', a[i]})
+    }
+    for ; j < m; j++ {
+        lines = append(lines, diffLine{'+', b[j]})
+    }
+    return lines
+}
+
+// unifiedDiff 返回 oldText 到 newText 的统一格式 diff, 没有差异时返回空字符串
+func unifiedDiff(name, oldText, newText string) string {
+    lines := diffLines(splitLines(oldText), splitLines(newText))
+    var sb strings.Builder
+    oldNo, newNo := 1, 1 // 当前行在旧文件和新文件中的行号
+    for start := 0; start < len(lines); {
+        if ' ' == lines[start].Kind {
+            oldNo++
+            newNo++
+            start++
+            continue
+        }
+        // 找到一个变更, 向前后扩展上下文, 相距不超过两倍上下文的变更合并到同一个块中
+        from := start - diffContext
+        if from < 0 {
+            from = 0
+        }
+        end, unchanged := start, 0
+        for ; end < len(lines) && unchanged <= 2*diffContext; end++ {
+            if ' ' == lines[end].Kind {
+                unchanged++
+            } else {
+                unchanged = 0
+            }
+        }
+        to := end - unchanged + diffContext
+        if to > len(lines) {
+            to = len(lines)
+        }
+        oldStart, newStart := oldNo-(start-from), newNo-(start-from)
+        oldCount, newCount := 0, 0
+        var hunk strings.Builder
+        for _, v := range lines[from:to] {
+            hunk.WriteByte(v.Kind)
+            hunk.WriteString(strings.TrimSuffix(v.Text, noNewline))
+            hunk.WriteByte('\n')
+            if strings.HasSuffix(v.Text, noNewline) {
+                hunk.WriteString("\\ No newline at end of file\n")
+            }
+            if '+' != v.Kind {
+                oldCount++
+            }
+            if '-' != v.Kind {
+                newCount++
+            }
+        }
+        if 0 == sb.Len() {
+            fmt.Fprintf(&sb, "--- a/%s\n+++ b/%s\n", name, name)
+        }
+        fmt.Fprintf(&sb, "@@ -%s +%s @@\n", hunkRange(oldStart, oldCount), hunkRange(newStart, newCount))
+        sb.WriteString(hunk.String())
+        for _, v := range lines[start:to] {
+            if '+' != v.Kind {
+                oldNo++
+            }
+            if '-' != v.Kind {
+                newNo++
+            }
+        }
+        start = to
+    }
+    return sb.String()
+}
+
+// hunkRange 格式化块的范围, 空范围的起始行为前一行
+func hunkRange(start, count int) string {
+    if 0 == count {
+        return fmt.Sprintf("%d,0", start-1)
+    }
+    if 1 == count {
+        return fmt.Sprintf("%d", start)
+    }
+    return fmt.Sprintf("%d,%d", start, count)
+}
@@ -0,0 +1,44 @@
+package cmd
+
+import (
+    "os"
+    "path/filepath"
+    "strings"
+    "testing"
+)
+
+// TestUnifiedDiff 比较 testdata/diff 中每组 name.old 与 name.new 的 diff 和 name.diff,
+// name.diff 由 diff -u --label a/name.java --label b/name.java name.old name.new 生成
+func TestUnifiedDiff(t *testing.T) {
+    files, err := filepath.Glob(filepath.Join("testdata", "diff", "*.old"))
+    if nil != err {
+        t.Fatal(err)
+    }
+    if 0 == len(files) {
+        t.Fatal("no golden files found in testdata/diff")
+    }
+    for _, v := range files {
+        name := strings.TrimSuffix(filepath.Base(v), ".old")
+        t.Run(name, func(t *testing.T) {
+            read := func(ext string) string {
+                data, err := os.ReadFile(filepath.Join("testdata", "diff", name+ext))
+                if nil != err {
+                    t.Fatal(err)
+                }
+                return string(data)
+            }
+            got := unifiedDiff(name+".java", read(".old"), read(".new"))
+            if want := read(".diff"); got != want {
+                t.Errorf("diff:\n%s\nwant:\n%s", got, want)
+            }
+        })
+    }
+}
+
+func TestUnifiedDiffUnchanged(t *testing.T) {
+    for _, v := range []string{"", "a\nb\n", "a\nb"} {
+        if got := unifiedDiff("a.java", v, v); "" != got {
+            t.Errorf("diff of %q with itself = %q, want empty", v, got)
+        }
+    }
+}
@@ -0,0 +1,101 @@
+package cmd
+
+import (
+    "fmt"
+
+    "github.com/fatih/color"
+)
+
+// 只渲染不写入时文件的状态
+const (
+    fileCreated   = "created"   // 文件不存在, 将会创建
+    fileChanged   = "changed"   // 文件存在且内容不同, 将会覆盖
+    fileUnchanged = "unchanged" // 文件存在且内容相同
+    fileSkipped   = "skipped"   // 用户维护的扩展文件, 已存在时不会覆盖
+    fileModified  = "modified"  // 上次生成后被手动修改过, 不会覆盖
+    fileOrphan    = "orphan"    // 表已经不存在, --prune 时会删除
+)
+
+type renderedFile struct {
+    Path   string // 相对于 rootPath 的路径
+    Status string
+    Diff   string // 与已有文件的统一格式 diff
+}
+
+var (
+    dryRun        *bool
+    renderOnly    bool           // 只渲染到内存, 不写入文件
+    renderedFiles []renderedFile // 只渲染时各个文件的状态
+)
+
+// recordRendered 记录只渲染时文件的状态, exists 为 false 时 existing 为空
+func recordRendered(fPath string, exists, modified bool, existing, content string) {
+    name := relPath(fPath)
+    file := renderedFile{Path: name}
+    switch {
+    case !exists:
+        file.Status = fileCreated
+        file.Diff = unifiedDiff(name, "", content)
+    case existing == content:
+        file.Status = fileUnchanged
+    case modified:
+        file.Status = fileModified
+        file.Diff = unifiedDiff(name, existing, content)
+    default:
+        file.Status = fileChanged
+        file.Diff = unifiedDiff(name, existing, content)
+    }
+    renderedFiles = append(renderedFiles, file)
+    if *dryRun {
+        printRendered(file)
+    }
+}
+
+// recordSkipped 记录只渲染时跳过的已有扩展文件
+func recordSkipped(fPath string) {
+    file := renderedFile{Path: relPath(fPath), Status: fileSkipped}
+    renderedFiles = append(renderedFiles, file)
+    if *dryRun {
+        printRendered(file)
+    }
+}
+
+// recordOrphan 记录只渲染时表已经不存在的文件
+func recordOrphan(v manifestFile) {
+    file := renderedFile{Path: v.Path, Status: fileOrphan}
+    renderedFiles = append(renderedFiles, file)
+    if *dryRun {
+        printRendered(file)
+    }
+}
+
+func printRendered(file renderedFile) {
+    switch file.Status {
+    case fileCreated:
+        color.Green("%-9s %s", file.Status, file.Path)
+    case fileChanged, fileModified, fileOrphan:
+        color.Yellow("%-9s %s", file.Status, file.Path)
+    default:
+        fmt.Printf("%-9s %s\n", file.Status, file.Path)
+    }
+    if "" != file.Diff {
+        fmt.Print(file.Diff)
+    }
+}
+
+// renderSummary 统计各个状态的文件数
+func renderSummary() map[string]int {
+    summary := map[string]int{}
+    for _, v := range renderedFiles {
+        summary[v.Status]++
+    }
+    return summary
+}
+
+// generated 输出生成成功的信息, 只渲染时不输出
+func generated(format string, a ...interface{}) {
+    if renderOnly {
+        return
+    }
+    color.Green(format, a...)
+}
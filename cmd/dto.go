@@ -0,0 +1,100 @@
+package cmd
+
+import (
+    "mybatis-export/config"
+    "os"
+    "strings"
+
+    "github.com/fatih/color"
+)
+
+// DtoExclude 各个 DTO 中排除的列, 可以是列名或者 表名.列名
+type DtoExclude struct {
+    Create []string `yaml:"create"` // XxxCreateRequest 中排除的列, 自增列和生成列总是排除
+    Update []string `yaml:"update"` // XxxUpdateRequest 中排除的列, 生成列总是排除
+    Vo     []string `yaml:"vo"`     // XxxVO 中排除的列
+}
+
+var dtoExclude DtoExclude
+
+// excluded 判断列是否在排除列表中
+func excluded(list []string, table string, c column) bool {
+    for _, v := range list {
+        v = strings.TrimSpace(v)
+        if i := strings.LastIndex(v, "."); -1 != i {
+            if !strings.EqualFold(v[:i], table) {
+                continue
+            }
+            v = v[i+1:]
+        }
+        if strings.EqualFold(v, c.Field) {
+            return true
+        }
+    }
+    return false
+}
+
+// dtoFields 返回 DTO 包含的列
+func dtoFields(temp *TemplateData, target string) []column {
+    var fields []column
+    for _, v := range temp.Fields {
+        switch target {
+        case "CreateRequest":
+            if 1 == v.IsAutoIncrement || 1 == v.IsGenerated || excluded(dtoExclude.Create, temp.TableName, v) {
+                continue
+            }
+        case "UpdateRequest":
+            if 1 == v.IsGenerated || excluded(dtoExclude.Update, temp.TableName, v) {
+                continue
+            }
+        case "VO":
+            if excluded(dtoExclude.Vo, temp.TableName, v) {
+                continue
+            }
+        }
+        fields = append(fields, v)
+    }
+    return fields
+}
+
+// generateDtos 生成 XxxCreateRequest, XxxUpdateRequest, XxxVO 以及 MapStruct 转换器
+func generateDtos(temp *TemplateData) {
+    for _, target := range []string{"CreateRequest", "UpdateRequest", "VO"} {
+        temp.DtoClass = target
+        temp.DtoFields = dtoFields(temp, target)
+        if err := generate(target, dtoTemp(), temp.DtoPackage, sourceSuffix(), temp); nil != err {
+            color.Red("Generate dto[%s.%s.%s%s] failed, err: %s\n", temp.PackagePath, temp.DtoPackage, temp.TableNameHump, target, err.Error())
+        } else {
+            generated("Generate dto[%s.%s.%s%s] success.", temp.PackagePath, temp.DtoPackage, temp.TableNameHump, target)
+        }
+    }
+    temp.DtoClass, temp.DtoFields = "", nil
+    if 1 != temp.Converter {
+        return
+    }
+    defaultTemp := config.ConverterTemp
+    if "kotlin" == language {
+        defaultTemp = config.KotlinConverterTemp
+    }
+    if err := generate("converter", defaultTemp, temp.DtoPackage, sourceSuffix(), temp); nil != err {
+        color.Red("Generate converter[%s.%s.%sConverter] failed, err: %s\n", temp.PackagePath, temp.DtoPackage, temp.TableNameHump, err.Error())
+    } else {
+        generated("Generate converter[%s.%s.%sConverter] success.", temp.PackagePath, temp.DtoPackage, temp.TableNameHump)
+    }
+}
+
+func dtoTemp() string {
+    defaultTemp := config.DtoTemp
+    if "kotlin" == language {
+        defaultTemp = config.KotlinDtoTemp
+    }
+    if "" == dtoTemplate {
+        return defaultTemp
+    }
+    dada, err := os.ReadFile(dtoTemplate)
+    if nil != err {
+        color.Yellow("Read dto template failed, err: %v, Use default.\n", err)
+        return defaultTemp
+    }
+    return string(dada)
+}
@@ -0,0 +1,53 @@
+package cmd
+
+import (
+    "mybatis-export/config"
+
+    "github.com/fatih/color"
+)
+
+// 基类模式(generation gap): 生成的代码写入总是覆盖的基类, 用户的代码写在仅创建一次的扩展类中
+
+// generateGapEntity 生成 XxxBaseEntity 以及继承它的 Xxx
+func generateGapEntity(temp *TemplateData) {
+    if err := generateFile("entity", entityTemp(), targetPath(entityPackage, temp.TableNameHump+"BaseEntity", sourceSuffix()), alwaysOverwrite, temp); nil != err {
+        color.Red("Generate base entity[%s.%s.%sBaseEntity] failed, err: %s\n", temp.PackagePath, temp.EntityPackage, temp.TableNameHump, err.Error())
+    } else {
+        generated("Generate base entity[%s.%s.%sBaseEntity] success.", temp.PackagePath, temp.EntityPackage, temp.TableNameHump)
+    }
+    if err := generateFile("entity", config.GapEntityTemp, targetPath(entityPackage, temp.TableNameHump, sourceSuffix()), createOnly, temp); nil != err {
+        color.Red("Generate entity[%s.%s.%s] failed, err: %s\n", temp.PackagePath, temp.EntityPackage, temp.TableNameHump, err.Error())
+    } else {
+        generated("Generate entity[%s.%s.%s] success.", temp.PackagePath, temp.EntityPackage, temp.TableNameHump)
+    }
+}
+
+// generateGapMapper 生成 XxxBaseMapper 以及继承它的 XxxMapper
+func generateGapMapper(temp *TemplateData) {
+    if err := generateFile("mapper", mapperTemp(), targetPath(mapperPackage, temp.TableNameHump+"BaseMapper", sourceSuffix()), alwaysOverwrite, temp); nil != err {
+        color.Red("Generate base mapper[%s.%s.%sBaseMapper] failed, err: %s\n", temp.PackagePath, temp.MapperPackage, temp.TableNameHump, err.Error())
+    } else {
+        generated("Generate base mapper[%s.%s.%sBaseMapper] success.", temp.PackagePath, temp.MapperPackage, temp.TableNameHump)
+    }
+    if err := generateFile("mapper", config.GapMapperTemp, targetPath(mapperPackage, temp.TableNameHump+"Mapper", sourceSuffix()), createOnly, temp); nil != err {
+        color.Red("Generate mapper[%s.%s.%sMapper] failed, err: %s\n", temp.PackagePath, temp.MapperPackage, temp.TableNameHump, err.Error())
+    } else {
+        generated("Generate mapper[%s.%s.%sMapper] success.", temp.PackagePath, temp.MapperPackage, temp.TableNameHump)
+    }
+}
+
+// generateGapMapperXml 生成 BaseXxxMapper.xml 以及同一 namespace 的 XxxMapper.xml
+func generateGapMapperXml(temp *TemplateData) {
+    basePath := targetPath(mapperXmlPath, "Base"+temp.TableNameHump+"Mapper", "xml")
+    if err := generateFile("mapper", mapperXmlTemp(), basePath, alwaysOverwrite, temp); nil != err {
+        color.Red("Generate base mapper xml[%s] failed, err: %s\n", basePath, err.Error())
+    } else {
+        generated("Generate base mapper xml[%s] success.", basePath)
+    }
+    extPath := targetPath(mapperXmlPath, temp.TableNameHump+"Mapper", "xml")
+    if err := generateFile("mapper", config.GapMapperXmlTemp, extPath, createOnly, temp); nil != err {
+        color.Red("Generate mapper xml[%s] failed, err: %s\n", extPath, err.Error())
+    } else {
+        generated("Generate mapper xml[%s] success.", extPath)
+    }
+}
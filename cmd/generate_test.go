@@ -0,0 +1,132 @@
+package cmd
+
+import (
+    "flag"
+    "os"
+    "path/filepath"
+    "testing"
+)
+
+var update = flag.Bool("update", false, "update the golden files in testdata/generate")
+
+// resetExport 将生成相关的全局状态重置为未提供任何参数时的值, 测试结束后恢复
+func resetExport(t *testing.T) {
+    oldAllTable, oldOverwriteAll, oldForce, oldPrune, oldDryRun := allTable, overwriteAll, force, prune, dryRun
+    oldKeyClass, oldMapstruct, oldGenerationGap, oldPort := keyClass, mapstruct, generationGap, port
+    allTable, overwriteAll, force, prune, dryRun = new(bool), new(bool), new(bool), new(bool), new(bool)
+    keyClass, mapstruct, generationGap, port = new(bool), new(bool), new(bool), new(uint16)
+    configPath, driver, schemaName, ddlPath, schemaFile, databaseName = "", "", "", "", "", ""
+    tableNames, tablePrefixListStr, tablePrefixs = nil, "", nil
+    rootPath, rootPackagePath, entityPackage, mapperXmlPath, mapperPackage, queryPackage = "", "", "", "", "", ""
+    servicePackage, serviceImplPackage, controllerPackage, dtoPackage = "", "", "", ""
+    queryTemplate, entityTemplate, mapperTemplate, mapperXmlTemplate, keyTemplate = "", "", "", "", ""
+    serviceTemplate, serviceImplTemplate, controllerTemplate, dtoTemplate = "", "", "", ""
+    dateTime, entityStyle, language, flavor, mapperMode = "", "", "", "", ""
+    logicDeleteColumn, versionColumn, lombokAnnotations, typeMappings, dtoExclude = "", "", nil, nil, DtoExclude{}
+    conflictOverwriteAll, conflictNoAll, renderOnly, nonInteractive, exportFailed = false, false, false, true, false
+    missingParams, renderedFiles = nil, nil
+    lastManifest, currentManifest = map[string]manifestFile{}, map[string]manifestFile{}
+    t.Cleanup(func() {
+        allTable, overwriteAll, force, prune, dryRun = oldAllTable, oldOverwriteAll, oldForce, oldPrune, oldDryRun
+        keyClass, mapstruct, generationGap, port = oldKeyClass, oldMapstruct, oldGenerationGap, oldPort
+        driver, nonInteractive = "", false
+    })
+}
+
+// readTree 读取目录下的所有文件, 键为相对路径, 不包含生成清单
+func readTree(t *testing.T, dir string) map[string]string {
+    files := map[string]string{}
+    err := filepath.Walk(dir, func(fPath string, info os.FileInfo, err error) error {
+        if nil != err || info.IsDir() || manifestName == info.Name() {
+            return err
+        }
+        data, err := os.ReadFile(fPath)
+        if nil != err {
+            return err
+        }
+        name, _ := filepath.Rel(dir, fPath)
+        files[filepath.ToSlash(name)] = string(data)
+        return nil
+    })
+    if nil != err {
+        t.Fatal(err)
+    }
+    return files
+}
+
+// TestExportTables 通过 --ddl 生成 testdata/generate 下各个 schema.sql 中的表, 与 golden 目录中的文件比较,
+// 修改模板后使用 go test ./cmd -run TestExportTables -update 更新 golden 文件
+func TestExportTables(t *testing.T) {
+    tests := []struct {
+        name  string
+        flags func()
+    }{
+        {name: "composite_pk"},
+        {name: "no_pk"},
+        {name: "indexes"},     // 唯一索引和普通索引
+        {name: "foreign_key"}, // 外键生成的关联
+        {name: "composite_pk_key_class", flags: func() {
+            ddlPath = filepath.Join("testdata", "generate", "composite_pk", "schema.sql")
+            *keyClass = true
+        }},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            resetExport(t)
+            dir := filepath.Join("testdata", "generate", tt.name)
+            ddlPath = filepath.Join(dir, "schema.sql")
+            rootPath = t.TempDir()
+            rootPackagePath, entityPackage, mapperPackage, queryPackage, mapperXmlPath = "work.bottle.demo", "entity", "mapper", "entity.query", "resource"
+            tablePrefixListStr = "bt_"
+            *allTable = true
+            if nil != tt.flags {
+                tt.flags()
+            }
+            if err := prepareExport(nil); nil != err {
+                t.Fatal(err)
+            }
+            if 0 < len(missingParams) {
+                t.Fatalf("missing parameters: %v", missingParams)
+            }
+            if err := exportTables(); nil != err {
+                t.Fatal(err)
+            }
+            if exportFailed {
+                t.Fatal("some files could not be generated")
+            }
+
+            golden := filepath.Join(dir, "golden")
+            got := readTree(t, rootPath)
+            if *update {
+                if err := os.RemoveAll(golden); nil != err {
+                    t.Fatal(err)
+                }
+                for name, content := range got {
+                    fPath := filepath.Join(golden, filepath.FromSlash(name))
+                    if err := os.MkdirAll(filepath.Dir(fPath), 0750); nil != err {
+                        t.Fatal(err)
+                    }
+                    if err := os.WriteFile(fPath, []byte(content), 0640); nil != err {
+                        t.Fatal(err)
+                    }
+                }
+                return
+            }
+            want := readTree(t, golden)
+            for name, content := range want {
+                if _, ok := got[name]; !ok {
+                    t.Errorf("%s is not generated", name)
+                    continue
+                }
+                if diff := unifiedDiff(name, content, got[name]); "" != diff {
+                    t.Errorf("%s differs from the golden file:\n%s", name, diff)
+                }
+            }
+            for name := range got {
+                if _, ok := want[name]; !ok {
+                    t.Errorf("%s is generated but not in %s", name, golden)
+                }
+            }
+        })
+    }
+}
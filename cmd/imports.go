@@ -0,0 +1,117 @@
+package cmd
+
+import (
+    "regexp"
+    "sort"
+    "strings"
+)
+
+// 全限定类名, 如 java.math.BigDecimal
+var qualifiedTypeRegexp = regexp.MustCompile(`\b(?:[a-z_$][\w$]*\.)+[A-Z][\w$]*`)
+
+// simpleType 将类型中的全限定类名替换为简单类名, 并返回需要的 import, 如 java.util.List<com.foo.Bar> 返回 List<Bar>
+func simpleType(javaType string) (string, []string) {
+    var imports []string
+    simple := qualifiedTypeRegexp.ReplaceAllStringFunc(javaType, func(name string) string {
+        imports = append(imports, name)
+        return name[strings.LastIndex(name, ".")+1:]
+    })
+    return simple, imports
+}
+
+// imports 生成排序、去重后的 import 块, pkg 为当前文件的包名, 同包以及 java.lang 的类不导入.
+// items 可以是类名、类名列表、注解、列(取列类型需要的 import)或索引(取索引列类型需要的 import)
+func imports(pkg string, items ...interface{}) string {
+    return importBlock(pkg, ";", items)
+}
+
+// ktimports 生成 Kotlin 的 import 块, 参数同 imports
+func ktimports(pkg string, items ...interface{}) string {
+    return importBlock(pkg, "", items)
+}
+
+func importBlock(pkg, end string, items []interface{}) string {
+    set := map[string]bool{}
+    add := func(names ...string) {
+        for _, name := range names {
+            if "" == name {
+                continue
+            }
+            i := strings.LastIndex(name, ".")
+            if i < 0 || name[:i] == pkg || name[:i] == "java.lang" {
+                continue
+            }
+            set[name] = true
+        }
+    }
+    for _, item := range items {
+        switch v := item.(type) {
+        case string:
+            add(v)
+        case []string:
+            add(v...)
+        case column:
+            add(v.Imports...)
+        case []column:
+            for _, c := range v {
+                add(c.Imports...)
+            }
+        case []annotation:
+            for _, a := range v {
+                add(a.Import)
+            }
+        case []index:
+            for _, idx := range v {
+                for _, c := range idx.Fields {
+                    add(c.Imports...)
+                }
+            }
+        }
+    }
+    var others, javas []string
+    for name := range set {
+        if strings.HasPrefix(name, "java.") || strings.HasPrefix(name, "javax.") {
+            javas = append(javas, "import "+name+end)
+        } else {
+            others = append(others, "import "+name+end)
+        }
+    }
+    sort.Strings(others)
+    sort.Strings(javas)
+    if 0 < len(others) && 0 < len(javas) {
+        others = append(others, "")
+    }
+    return strings.Join(append(others, javas...), "\n")
+}
+
+// paramImport 有方法的参数使用 @Param 时返回其 import, 否则为空
+func paramImport(temp *TemplateData) string {
+    if (0 < len(temp.Pks) && 1 != temp.KeyClass) || 0 < len(temp.UniqueIndexes) || 0 < len(temp.ListIndexes) {
+        return "org.apache.ibatis.annotations.Param"
+    }
+    return ""
+}
+
+// indexed 返回索引列, 即 query 中可作为查询条件的列
+func indexed(fields []column) []column {
+    var list []column
+    for _, v := range fields {
+        if 1 == v.IsIndex {
+            list = append(list, v)
+        }
+    }
+    return list
+}
+
+// qualifiedType 返回简单类名对应的全限定类名, 用于 xml 中的 javaType
+func qualifiedType(javaType string, imports []string) string {
+    for _, v := range imports {
+        if strings.HasSuffix(v, "."+javaType) {
+            return v
+        }
+    }
+    if !strings.ContainsAny(javaType, ".[<") {
+        return "java.lang." + javaType
+    }
+    return javaType
+}
@@ -0,0 +1,101 @@
+package cmd
+
+import (
+    "github.com/fatih/color"
+    "github.com/spf13/cobra"
+    "mybatis-export/schema"
+    "os"
+    "path/filepath"
+    "strings"
+)
+
+var (
+    inspectOutput string // 快照输出路径, 为空时输出到标准输出
+    inspectFormat string // 快照格式: json, yaml
+)
+
+// inspectCmd 导出表结构快照, 之后可通过 --schema-file 从快照生成代码
+var inspectCmd = &cobra.Command{
+    Use:   "inspect [database] [tables...]",
+    Short: "export the schema of tables to a json or yaml snapshot",
+    PreRunE: func(cmd *cobra.Command, args []string) error {
+        if "" != inspectOutput { // 读取配置文件时会切换工作目录
+            fullPath, err := filepath.Abs(inspectOutput)
+            if nil != err {
+                return err
+            }
+            inspectOutput = fullPath
+        }
+        loadConfigFile()
+        return prepareSource(args)
+    },
+    Run: func(cmd *cobra.Command, args []string) {
+        provider, err := newProvider()
+        if nil != err {
+            color.Red("Error: %v\n", err)
+            return
+        }
+        defer provider.Close()
+
+        tables, err := provider.Tables(tableNames)
+        if nil != err {
+            color.Red("Error: Query all table of %s failed. err: %v\n", databaseName, err)
+            return
+        }
+        snapshot := schema.Snapshot{Driver: driver, Database: databaseName}
+        for _, v := range tables {
+            columns, err := provider.Columns(v.Name)
+            if nil != err {
+                color.Red("Query table %v failed, err: %v\n", v.Name, err)
+                return
+            }
+            indexes, err := provider.Indexes(v.Name)
+            if nil != err {
+                color.Red("Query index of table %v failed, err: %v\n", v.Name, err)
+                return
+            }
+            fks, err := provider.ForeignKeys(v.Name)
+            if nil != err {
+                color.Red("Query foreign key of table %v failed, err: %v\n", v.Name, err)
+                return
+            }
+            table := schema.SnapshotTable{Table: v, Indexes: indexes}
+            for _, fk := range fks {
+                if fk.Table == v.Name {
+                    table.ForeignKeys = append(table.ForeignKeys, fk)
+                }
+            }
+            for _, c := range columns {
+                column := schema.SnapshotColumn{Column: c, IsPk: "PRI" == c.Key}
+                column.JdbcType, column.JavaType, _ = columnType(c.Name, c.DataType, c.ColumnType)
+                table.Columns = append(table.Columns, column)
+            }
+            snapshot.Tables = append(snapshot.Tables, table)
+        }
+
+        format := strings.ToLower(inspectFormat)
+        if "" == format {
+            format = snapshotFormat(inspectOutput)
+        }
+        data, err := snapshot.Marshal(format)
+        if nil != err {
+            color.Red("Error: %v\n", err)
+            return
+        }
+        if "" == inspectOutput {
+            os.Stdout.Write(data)
+            return
+        }
+        if err := os.WriteFile(inspectOutput, data, 0640); nil != err {
+            color.Red("Write schema snapshot[%s] failed, err: %v\n", inspectOutput, err)
+            return
+        }
+        color.Green("Export schema snapshot[%s] success, %d tables.\n", inspectOutput, len(snapshot.Tables))
+    },
+}
+
+func init() {
+    rootCmd.AddCommand(inspectCmd)
+    inspectCmd.Flags().StringVarP(&inspectOutput, "output", "O", "", "the path of schema snapshot, print to stdout if not provided")
+    inspectCmd.Flags().StringVar(&inspectFormat, "format", "", "the format of schema snapshot, json or yaml, the default is decided by the extension of output")
+}
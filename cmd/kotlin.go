@@ -0,0 +1,62 @@
+package cmd
+
+import (
+    "strings"
+)
+
+// Java 类型对应的 Kotlin 类型, 未列出的类型保持不变
+var kotlinTypes = map[string]string{
+    "Integer": "Int",
+    "Object":  "Any",
+    "Byte[]":  "Array<Byte>",
+    "byte[]":  "ByteArray",
+}
+
+// Kotlin 类型的零值, 非空列使用零值作为默认值, 以便生成无参构造函数
+var kotlinZeroValues = map[string]string{
+    "Int":     "0",
+    "Long":    "0L",
+    "Short":   "0",
+    "Byte":    "0",
+    "Float":   "0F",
+    "Double":  "0.0",
+    "Boolean": "false",
+    "String":  `""`,
+}
+
+// Kotlin 的硬关键字, 作为属性名时需要使用反引号
+var kotlinKeywords = []string{"as", "break", "class", "continue", "do", "else", "false", "for", "fun", "if", "in",
+    "interface", "is", "null", "object", "package", "return", "super", "this", "throw", "true", "try", "typealias",
+    "typeof", "val", "var", "when", "while"}
+
+// kotlinType 返回 Java 类型对应的 Kotlin 类型
+func kotlinType(javaType string) string {
+    if v, ok := kotlinTypes[javaType]; ok {
+        return v
+    }
+    if strings.HasSuffix(javaType, "[]") {
+        return "Array<" + kotlinType(strings.TrimSuffix(javaType, "[]")) + ">"
+    }
+    return javaType
+}
+
+// fillKotlinType 设置列的 Kotlin 类型, 只有可为空的列使用可空类型, 非空列使用非空类型并以零值作为默认值.
+// 两个例外: 自增列插入时需要为 null, insert 语句才不会写入该列而由数据库生成; 没有零值的类型(如 BigDecimal)无法给出默认值
+func fillKotlinType(c *column) {
+    c.KotlinType = kotlinType(c.JavaType)
+    zero, ok := kotlinZeroValues[c.KotlinType]
+    if 1 == c.IsNullable || 1 == c.IsAutoIncrement || !ok {
+        c.KotlinNullable = 1
+        c.KotlinDefault = "null"
+    } else {
+        c.KotlinDefault = zero
+    }
+}
+
+// ktname 属性名为 Kotlin 关键字时加上反引号
+func ktname(name string) string {
+    if contains(kotlinKeywords, name) {
+        return "`" + name + "`"
+    }
+    return name
+}
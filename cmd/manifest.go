@@ -0,0 +1,219 @@
+package cmd
+
+import (
+    "crypto/sha256"
+    "encoding/hex"
+    "encoding/json"
+    "os"
+    "path/filepath"
+    "sort"
+    "strings"
+
+    "github.com/fatih/color"
+    "mybatis-export/schema"
+)
+
+// 生成清单的文件名, 位于 rootPath 下
+const manifestName = ".mybatis-export.manifest.json"
+
+type manifestFile struct {
+    Path     string `json:"path"`     // 相对于 rootPath 的路径
+    Table    string `json:"table"`    // 来源表
+    Template string `json:"template"` // 使用的模板, 见 templateName
+    Hash     string `json:"hash"`     // 内容的 sha256, 不包含自定义区域中的内容
+}
+
+type generationManifest struct {
+    Files []manifestFile `json:"files"`
+}
+
+var (
+    prune           *bool
+    force           *bool
+    lastManifest    = map[string]manifestFile{} // 上次生成的清单
+    currentManifest = map[string]manifestFile{} // 本次生成的文件
+)
+
+// relPath 返回相对于 rootPath 的路径
+func relPath(fPath string) string {
+    name, err := filepath.Rel(rootPath, fPath)
+    if nil != err {
+        name = fPath
+    }
+    return filepath.ToSlash(name)
+}
+
+// templateName 生成文件所用的模板, 自定义模板为其路径, 内置模板为 -g 导出的模板文件名
+func templateName(tempStr string) string {
+    for _, v := range []string{entityTemplate, mapperTemplate, mapperXmlTemplate, keyTemplate, queryTemplate,
+        serviceTemplate, serviceImplTemplate, controllerTemplate, dtoTemplate} {
+        if "" == v {
+            continue
+        }
+        if data, err := os.ReadFile(v); nil == err && string(data) == tempStr {
+            return relPath(v)
+        }
+    }
+    for _, v := range templateFiles {
+        if v.content == tempStr {
+            return v.name
+        }
+    }
+    return ""
+}
+
+// contentHash 计算内容的 hash, 自定义区域中的内容由用户维护, 不参与计算
+func contentHash(content string) string {
+    var sb strings.Builder
+    inRegion := false
+    for _, line := range strings.SplitAfter(content, "\n") {
+        trimmed := strings.TrimRight(line, "\r\n")
+        if inRegion {
+            if !customEndRegexp.MatchString(trimmed) {
+                continue
+            }
+            inRegion = false
+        } else if customBeginRegexp.MatchString(trimmed) {
+            inRegion = true
+        }
+        sb.WriteString(line)
+    }
+    sum := sha256.Sum256([]byte(sb.String()))
+    return hex.EncodeToString(sum[:])
+}
+
+// loadManifest 读取上次生成的清单, 不存在时为空
+func loadManifest() {
+    data, err := os.ReadFile(filepath.Join(rootPath, manifestName))
+    if nil != err {
+        if !os.IsNotExist(err) {
+            color.Yellow("Read manifest failed, err: %v\n", err)
+        }
+        return
+    }
+    var manifest generationManifest
+    if err := json.Unmarshal(data, &manifest); nil != err {
+        color.Yellow("Parse manifest[%s] failed, it will be rebuilt, err: %v\n", manifestName, err)
+        return
+    }
+    for _, v := range manifest.Files {
+        lastManifest[v.Path] = v
+    }
+}
+
+// saveManifest 写入本次生成的文件, 以及上次生成但本次未涉及且仍然存在的文件
+func saveManifest() error {
+    var manifest generationManifest
+    for _, v := range currentManifest {
+        manifest.Files = append(manifest.Files, v)
+    }
+    for k, v := range lastManifest {
+        if _, ok := currentManifest[k]; ok {
+            continue
+        }
+        if _, err := os.Stat(filepath.Join(rootPath, filepath.FromSlash(k))); nil != err {
+            continue
+        }
+        manifest.Files = append(manifest.Files, v)
+    }
+    sort.Slice(manifest.Files, func(i, j int) bool {
+        return manifest.Files[i].Path < manifest.Files[j].Path
+    })
+    data, err := json.MarshalIndent(manifest, "", "  ")
+    if nil != err {
+        return err
+    }
+    return os.WriteFile(filepath.Join(rootPath, manifestName), append(data, '\n'), 0640)
+}
+
+// recordManifest 记录本次生成的文件
+func recordManifest(fPath, template, table, content string) {
+    name := relPath(fPath)
+    currentManifest[name] = manifestFile{Path: name, Table: table, Template: template, Hash: contentHash(content)}
+}
+
+// keepManifest 文件本次没有写入时保留上次的记录
+func keepManifest(fPath string) {
+    name := relPath(fPath)
+    if v, ok := lastManifest[name]; ok {
+        currentManifest[name] = v
+    }
+}
+
+// handEdited 文件在上次生成之后是否被手动修改过, 自定义区域中的修改不算
+func handEdited(fPath string) bool {
+    v, ok := lastManifest[relPath(fPath)]
+    if !ok {
+        return false
+    }
+    data, err := os.ReadFile(fPath)
+    if nil != err {
+        return false
+    }
+    return v.Hash != contentHash(string(data))
+}
+
+// hasCustomCode 文件的自定义区域中是否有用户的代码
+func hasCustomCode(fPath string) bool {
+    data, err := os.ReadFile(fPath)
+    if nil != err {
+        return false
+    }
+    for _, v := range customRegions(string(data)) {
+        if "" != strings.TrimSpace(v.Content) {
+            return true
+        }
+    }
+    return false
+}
+
+// pruneOrphans 找出表已经不存在(删除或重命名)的文件, --prune 时删除未被手动修改过的文件
+func pruneOrphans(provider schema.Provider) error {
+    if 0 == len(lastManifest) {
+        return nil
+    }
+    tables, err := provider.Tables(nil)
+    if nil != err {
+        return err
+    }
+    exists := map[string]bool{}
+    for _, v := range tables {
+        exists[v.Name] = true
+    }
+    var orphans []manifestFile
+    for _, v := range lastManifest {
+        if !exists[v.Table] {
+            orphans = append(orphans, v)
+        }
+    }
+    sort.Slice(orphans, func(i, j int) bool {
+        return orphans[i].Path < orphans[j].Path
+    })
+    for _, v := range orphans {
+        fPath := filepath.Join(rootPath, filepath.FromSlash(v.Path))
+        if _, err := os.Stat(fPath); os.IsNotExist(err) {
+            delete(lastManifest, v.Path)
+            continue
+        }
+        if renderOnly {
+            recordOrphan(v)
+            continue
+        }
+        if !*prune {
+            color.Yellow("The file[%s] is orphaned, the table[%s] no longer exists, use --prune to delete it\n", v.Path, v.Table)
+            continue
+        }
+        if handEdited(fPath) || hasCustomCode(fPath) {
+            color.Yellow("The orphaned file[%s] has been modified by hand, please delete it by yourself\n", v.Path)
+            continue
+        }
+        if err := os.Remove(fPath); nil != err {
+            color.Red("Prune file[%s] failed, err: %v\n", v.Path, err)
+            exportFailed = true
+            continue
+        }
+        delete(lastManifest, v.Path)
+        color.Green("Prune file[%s] of table[%s] success.", v.Path, v.Table)
+    }
+    return nil
+}
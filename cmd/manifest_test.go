@@ -0,0 +1,176 @@
+package cmd
+
+import (
+    "os"
+    "path/filepath"
+    "testing"
+
+    "mybatis-export/schema"
+)
+
+// withManifest 在临时目录中生成文件, 测试结束后恢复生成相关的全局状态
+func withManifest(t *testing.T) {
+    oldRootPath, oldLast, oldCurrent := rootPath, lastManifest, currentManifest
+    oldPrune, oldForce, oldOverwriteAll, oldRenderOnly, oldFailed := prune, force, conflictOverwriteAll, renderOnly, exportFailed
+    rootPath, lastManifest, currentManifest = t.TempDir(), map[string]manifestFile{}, map[string]manifestFile{}
+    prune, force, conflictOverwriteAll, renderOnly, exportFailed = new(bool), new(bool), true, false, false
+    t.Cleanup(func() {
+        rootPath, lastManifest, currentManifest = oldRootPath, oldLast, oldCurrent
+        prune, force, conflictOverwriteAll, renderOnly, exportFailed = oldPrune, oldForce, oldOverwriteAll, oldRenderOnly, oldFailed
+    })
+}
+
+// nextRun 模拟下一次生成: 本次的清单成为上次的清单
+func nextRun() {
+    lastManifest, currentManifest = currentManifest, map[string]manifestFile{}
+}
+
+func readFile(t *testing.T, fPath string) string {
+    data, err := os.ReadFile(fPath)
+    if nil != err {
+        t.Fatal(err)
+    }
+    return string(data)
+}
+
+func writeFile(t *testing.T, fPath, content string) {
+    if err := os.MkdirAll(filepath.Dir(fPath), 0750); nil != err {
+        t.Fatal(err)
+    }
+    if err := os.WriteFile(fPath, []byte(content), 0640); nil != err {
+        t.Fatal(err)
+    }
+}
+
+func TestContentHash(t *testing.T) {
+    generated := "class A {\n    // @custom-begin methods\n    // @custom-end\n}\n"
+    tests := []struct {
+        name    string
+        content string
+        same    bool
+    }{
+        {"identical", generated, true},
+        {"custom region content", "class A {\n    // @custom-begin methods\n    void m() {}\n    // @custom-end\n}\n", true},
+        {"outside custom region", "class A {\n    int x;\n    // @custom-begin methods\n    // @custom-end\n}\n", false},
+        {"custom region renamed", "class A {\n    // @custom-begin others\n    // @custom-end\n}\n", false},
+        {"trailing newline", "class A {\n    // @custom-begin methods\n    // @custom-end\n}", false},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            if same := contentHash(tt.content) == contentHash(generated); same != tt.same {
+                t.Errorf("same hash = %v, want %v", same, tt.same)
+            }
+        })
+    }
+}
+
+func TestGenerateFileHandEdited(t *testing.T) {
+    withManifest(t)
+    fPath := filepath.Join(rootPath, "entity", "A.java")
+    temp := &TemplateData{TableName: "a"}
+    v1 := "class A {\n    // @custom-begin methods\n    // @custom-end\n}\n"
+    v2 := "class A {\n    int x;\n    // @custom-begin methods\n    // @custom-end\n}\n"
+    if err := generateFile("", v1, fPath, askOverwrite, temp); nil != err {
+        t.Fatal(err)
+    }
+    if v := currentManifest["entity/A.java"]; v.Table != "a" || v.Hash != contentHash(v1) {
+        t.Fatalf("manifest = %+v", v)
+    }
+
+    // 只修改自定义区域不算手动修改, 重新生成时覆盖并保留自定义区域
+    nextRun()
+    custom := "class A {\n    // @custom-begin methods\n    void m() {}\n    // @custom-end\n}\n"
+    writeFile(t, fPath, custom)
+    if err := generateFile("", v2, fPath, askOverwrite, temp); nil != err {
+        t.Fatal(err)
+    }
+    want := "class A {\n    int x;\n    // @custom-begin methods\n    void m() {}\n    // @custom-end\n}\n"
+    if got := readFile(t, fPath); got != want {
+        t.Fatalf("content:\n%s\nwant:\n%s", got, want)
+    }
+
+    // 自定义区域之外的修改, 没有 --force 时不覆盖, 并保留上次的清单
+    nextRun()
+    edited := "class A {\n    int y;\n    // @custom-begin methods\n    void m() {}\n    // @custom-end\n}\n"
+    writeFile(t, fPath, edited)
+    if err := generateFile("", v1, fPath, askOverwrite, temp); nil != err {
+        t.Fatal(err)
+    }
+    if got := readFile(t, fPath); got != edited {
+        t.Fatalf("hand edited file is overwritten:\n%s", got)
+    }
+    if v := currentManifest["entity/A.java"]; v.Hash != contentHash(want) {
+        t.Errorf("manifest of the skipped file = %+v, want the last one", v)
+    }
+    if err := generateFile("", v1, fPath, alwaysOverwrite, temp); nil != err { // 基类模式下总是覆盖的文件同样不覆盖
+        t.Fatal(err)
+    }
+    if got := readFile(t, fPath); got != edited {
+        t.Fatalf("hand edited base file is overwritten:\n%s", got)
+    }
+
+    // --force 时覆盖, 自定义区域仍然保留
+    *force = true
+    if err := generateFile("", v1, fPath, askOverwrite, temp); nil != err {
+        t.Fatal(err)
+    }
+    want = "class A {\n    // @custom-begin methods\n    void m() {}\n    // @custom-end\n}\n"
+    if got := readFile(t, fPath); got != want {
+        t.Fatalf("content:\n%s\nwant:\n%s", got, want)
+    }
+    if exportFailed {
+        t.Error("exportFailed is set")
+    }
+}
+
+func TestPruneOrphans(t *testing.T) {
+    withManifest(t)
+    provider, err := schema.NewDdlProvider("create table kept (id int);")
+    if nil != err {
+        t.Fatal(err)
+    }
+    generated := "class A {\n    // @custom-begin methods\n    // @custom-end\n}\n"
+    files := []struct {
+        path    string
+        table   string
+        content string
+        pruned  bool
+    }{
+        {"entity/Dropped.java", "dropped", generated, true},
+        {"entity/Custom.java", "dropped", "class A {\n    // @custom-begin methods\n    void m() {}\n    // @custom-end\n}\n", false},
+        {"entity/Edited.java", "dropped", "class A {\n    int x;\n    // @custom-begin methods\n    // @custom-end\n}\n", false},
+        {"entity/Kept.java", "kept", generated, false},
+    }
+    for _, v := range files {
+        writeFile(t, filepath.Join(rootPath, filepath.FromSlash(v.path)), v.content)
+        lastManifest[v.path] = manifestFile{Path: v.path, Table: v.table, Hash: contentHash(generated)}
+    }
+    lastManifest["entity/Deleted.java"] = manifestFile{Path: "entity/Deleted.java", Table: "dropped", Hash: contentHash(generated)}
+
+    // 没有 --prune 时只提示
+    if err := pruneOrphans(provider); nil != err {
+        t.Fatal(err)
+    }
+    for _, v := range files {
+        if _, err := os.Stat(filepath.Join(rootPath, filepath.FromSlash(v.path))); nil != err {
+            t.Errorf("%s is deleted without --prune", v.path)
+        }
+    }
+    if _, ok := lastManifest["entity/Deleted.java"]; ok {
+        t.Error("the file deleted by hand is still in the manifest")
+    }
+
+    *prune = true
+    if err := pruneOrphans(provider); nil != err {
+        t.Fatal(err)
+    }
+    for _, v := range files {
+        _, err := os.Stat(filepath.Join(rootPath, filepath.FromSlash(v.path)))
+        if pruned := os.IsNotExist(err); pruned != v.pruned {
+            t.Errorf("%s: pruned = %v, want %v", v.path, pruned, v.pruned)
+        }
+        if _, ok := lastManifest[v.path]; ok == v.pruned {
+            t.Errorf("%s: in manifest = %v, want %v", v.path, ok, !v.pruned)
+        }
+    }
+}
@@ -0,0 +1,86 @@
+package cmd
+
+import (
+    "database/sql"
+    "fmt"
+    _ "github.com/go-sql-driver/mysql"
+    _ "github.com/lib/pq"
+    _ "modernc.org/sqlite"
+    "mybatis-export/config"
+    "mybatis-export/schema"
+    "os"
+    "path/filepath"
+    "strings"
+    "time"
+)
+
+// newProvider 根据参数创建表结构来源
+func newProvider() (schema.Provider, error) {
+    if "" != schemaFile {
+        data, err := os.ReadFile(schemaFile)
+        if nil != err {
+            return nil, fmt.Errorf("read schema file[%s] failed, err: %v", schemaFile, err)
+        }
+        snapshot, err := schema.ParseSnapshot(data, snapshotFormat(schemaFile))
+        if nil != err {
+            return nil, fmt.Errorf("parse schema file[%s] failed, err: %v", schemaFile, err)
+        }
+        if "" == driver {
+            driver = snapshot.Driver
+        }
+        if "" == driver {
+            driver = "mysql"
+        }
+        return schema.NewSnapshotProvider(snapshot), nil
+    }
+    if "" != ddlPath {
+        data, err := os.ReadFile(ddlPath)
+        if nil != err {
+            return nil, fmt.Errorf("read ddl file[%s] failed, err: %v", ddlPath, err)
+        }
+        return schema.NewDdlProvider(string(data))
+    }
+    if "sqlite" == driver {
+        if _, err := os.Stat(databaseName); nil != err {
+            return nil, fmt.Errorf("open sqlite database[%s] failed, err: %v", databaseName, err)
+        }
+        db, err := sql.Open("sqlite", "file:"+databaseName+"?mode=ro")
+        if nil != err {
+            return nil, fmt.Errorf("open sqlite failed, err: %v", err)
+        }
+        config.DbIns = db
+        return schema.NewSqliteProvider(db), nil
+    }
+
+    var dsn string
+    switch driver {
+    case "postgres":
+        dsn = fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=disable", host, *port, user, password, databaseName)
+    default:
+        dsn = fmt.Sprintf("%s:%s@%s(%s:%d)/%s?parseTime=1&multiStatements=1&charset=utf8mb4&collation=utf8mb4_unicode_ci", user, password, "tcp", host, *port, "information_schema")
+    }
+
+    var err error
+    config.DbIns, err = sql.Open(driver, dsn)
+    if nil != err {
+        return nil, fmt.Errorf("open %s failed, err: %v", driver, err)
+    }
+    //最大连接周期，超过时间的连接就close
+    config.DbIns.SetConnMaxLifetime(100 * time.Second)
+    //设置最大连接数
+    config.DbIns.SetMaxOpenConns(100)
+    //设置闲置连接数
+    config.DbIns.SetMaxIdleConns(16)
+    if "postgres" == driver {
+        return schema.NewPostgresProvider(config.DbIns, schemaName), nil
+    }
+    return schema.NewMysqlProvider(config.DbIns, databaseName), nil
+}
+
+// snapshotFormat 根据文件扩展名判断快照格式, .json 为 json, 其他为 yaml
+func snapshotFormat(path string) string {
+    if strings.EqualFold(filepath.Ext(path), ".json") {
+        return "json"
+    }
+    return "yaml"
+}
@@ -0,0 +1,185 @@
+package cmd
+
+import (
+    "fmt"
+    "mybatis-export/schema"
+    "strings"
+
+    "github.com/fatih/color"
+)
+
+// exportedTables 本次生成的表, 关联的表不在其中时不生成关联关系, 为 nil 时不限制
+var exportedTables map[string]bool
+
+type relation struct {
+    Name          string   // 外键名
+    Property      string   // 实体中的属性名
+    PropertyN     string   // 首字母大写的属性名
+    TableName     string   // 关联的表
+    TableNameHump string   // 关联表的实体类名
+    Fields        []column // 本表参与关联的列, 按外键内的顺序排列
+    Select        string   // 关联表 mapper 中用于嵌套查询的方法, 如 getByPk, listByUserId
+    Column        string   // 传给嵌套查询的列, 单列时为列名, 多列时为 {property=column,...}
+}
+
+// fillRelations 根据外键生成关联关系:
+// 本表引用的表生成 association, 引用本表的表生成 collection, 本表的外键列生成 listByXxx
+func fillRelations(provider schema.Provider, temp *TemplateData) error {
+    fks, err := provider.ForeignKeys(temp.TableName)
+    if nil != err {
+        return err
+    }
+    used := map[string]bool{}
+    for _, v := range temp.Fields {
+        used[v.Property] = true
+    }
+    for _, fk := range fks {
+        if fk.Table != temp.TableName {
+            continue
+        }
+        fields := fieldsOf(temp, fk.Columns)
+        if len(fields) != len(fk.Columns) {
+            continue
+        }
+        addListIndex(temp, fields)
+        if !relationExported(fk, fk.RefTable) {
+            continue
+        }
+        selectId, err := referenceSelect(provider, fk)
+        if nil != err {
+            return err
+        }
+        if "" == selectId {
+            continue
+        }
+        rel := relation{
+            Name:          fk.Name,
+            TableName:     fk.RefTable,
+            TableNameHump: tableNameHump(fk.RefTable),
+            Fields:        fields,
+            Select:        selectId,
+        }
+        property := relationName(rel.TableNameHump, fk.RefTable)
+        if 1 == len(fk.Columns) && strings.HasSuffix(strings.ToLower(fk.Columns[0]), "_id") {
+            if name := toHump(fk.Columns[0][:len(fk.Columns[0])-3], false); "" != name { // 列名只有 _id 时使用关联表的类名
+                property = name
+            }
+        }
+        if used[property] {
+            property += finder(fk.Columns)
+        }
+        rel.Property, rel.PropertyN = property, ucfirst(property)
+        used[property] = true
+        rel.Column = nestedColumn(fk.RefColumns, fk.Columns)
+        temp.Associations = append(temp.Associations, rel)
+    }
+    for _, fk := range fks {
+        if fk.RefTable != temp.TableName {
+            continue
+        }
+        fields := fieldsOf(temp, fk.RefColumns)
+        if len(fields) != len(fk.RefColumns) || !relationExported(fk, fk.Table) {
+            continue
+        }
+        rel := relation{
+            Name:          fk.Name,
+            TableName:     fk.Table,
+            TableNameHump: tableNameHump(fk.Table),
+            Fields:        fields,
+            Select:        "list" + finder(fk.Columns),
+        }
+        property := relationName(rel.TableNameHump, fk.Table) + "List"
+        if used[property] {
+            property += finder(fk.Columns)
+        }
+        rel.Property, rel.PropertyN = property, ucfirst(property)
+        used[property] = true
+        rel.Column = nestedColumn(fk.Columns, fk.RefColumns)
+        temp.Collections = append(temp.Collections, rel)
+    }
+    return nil
+}
+
+// relationExported 关联的表是否在本次生成的表中, 不在时其 mapper 中没有嵌套查询的方法, 跳过该关联
+func relationExported(fk schema.ForeignKey, table string) bool {
+    if nil == exportedTables || exportedTables[table] {
+        return true
+    }
+    color.Yellow("The relation[%s] between %s and %s is skipped, the table[%s] is not exported\n", fk.Name, fk.Table, fk.RefTable, table)
+    return false
+}
+
+// relationName 由关联表的类名得到属性名, 去掉表前缀后为空时使用完整的表名
+func relationName(tableNameHump, tableName string) string {
+    if "" == tableNameHump {
+        return lcfirst(toHump(tableName, true))
+    }
+    return lcfirst(tableNameHump)
+}
+
+// referenceSelect 返回被引用表中按外键引用列查询单条记录的方法, 引用列既不是主键也不是唯一索引时返回空
+func referenceSelect(provider schema.Provider, fk schema.ForeignKey) (string, error) {
+    pks, err := provider.PrimaryKeys(fk.RefTable)
+    if nil != err {
+        return "", err
+    }
+    if sameColumns(pks, fk.RefColumns) {
+        return "getByPk", nil
+    }
+    indexes, err := provider.Indexes(fk.RefTable)
+    if nil != err {
+        return "", err
+    }
+    for _, v := range indexes {
+        if v.Unique && !v.Primary && sameColumns(v.Columns, fk.RefColumns) {
+            return "get" + finder(v.Columns), nil
+        }
+    }
+    return "", nil
+}
+
+// nestedColumn 生成 association/collection 的 column 属性, params 为嵌套查询的参数列, columns 为本表中对应的列
+func nestedColumn(params, columns []string) string {
+    if 1 == len(columns) {
+        return columns[0]
+    }
+    pairs := make([]string, len(columns))
+    for i, v := range columns {
+        pairs[i] = fmt.Sprintf("%s=%s", toHump(params[i], false), v)
+    }
+    return "{" + strings.Join(pairs, ",") + "}"
+}
+
+// finder 生成查询方法名后缀, 如 ByTenantIdAndCode
+func finder(columns []string) string {
+    names := make([]string, len(columns))
+    for i, v := range columns {
+        names[i] = toHump(v, true)
+    }
+    return "By" + strings.Join(names, "And")
+}
+
+func fieldsOf(temp *TemplateData, names []string) []column {
+    var fields []column
+    for _, name := range names {
+        for _, v := range temp.Fields {
+            if v.Field == name {
+                fields = append(fields, v)
+                break
+            }
+        }
+    }
+    return fields
+}
+
+func sameColumns(a, b []string) bool {
+    if 0 == len(a) || len(a) != len(b) {
+        return false
+    }
+    for _, v := range b {
+        if !contains(a, v) {
+            return false
+        }
+    }
+    return true
+}
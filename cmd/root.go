@@ -19,26 +19,30 @@ limitations under the License.
 package cmd
 
 import (
-    "database/sql"
+    "bytes"
     "errors"
     "fmt"
     "github.com/fatih/color"
-    _ "github.com/go-sql-driver/mysql"
     "github.com/spf13/cobra"
     "gopkg.in/yaml.v3"
     "mybatis-export/config"
+    "mybatis-export/schema"
     "mybatis-export/util"
     "os"
     "path/filepath"
+    "sort"
     "strings"
     "text/template"
-    "time"
 )
 
 var (
     isHelp             *bool
     generateTemplate   string // 是否是生成模板
     configPath         string // 配置文件目录
+    driver             string // 数据库驱动: mysql, postgres, sqlite
+    schemaName         string // PostgreSQL 的 schema
+    ddlPath            string // DDL 文件路径, 提供时不连接数据库
+    schemaFile         string // 表结构快照文件路径, 提供时不连接数据库
     host               string
     user               string
     password           string
@@ -48,77 +52,171 @@ var (
     tablePrefixListStr string
     tablePrefixs       []string
 
-    rootPath         string
-    rootPackagePath  string
-    entityPackage    string
-    mapperXmlPath    string
-    mapperPackage    string
-    queryPackage     string
-    queryRootPackage string
-    allTable         *bool
-    overwriteAll     *bool
+    rootPath           string
+    rootPackagePath    string
+    entityPackage      string
+    mapperXmlPath      string
+    mapperPackage      string
+    queryPackage       string
+    queryRootPackage   string
+    servicePackage     string
+    serviceImplPackage string
+    controllerPackage  string
+    dtoPackage         string
+    allTable           *bool
+    overwriteAll       *bool
 
     conflictOverwriteAll bool = false
     conflictNoAll             = false
     interact             util.Interact
 
-    queryTemplate     string
-    entityTemplate    string
-    mapperTemplate    string
-    mapperXmlTemplate string
+    queryTemplate       string
+    entityTemplate      string
+    mapperTemplate      string
+    mapperXmlTemplate   string
+    keyTemplate         string
+    serviceTemplate     string
+    serviceImplTemplate string
+    controllerTemplate  string
+    dtoTemplate         string
+    keyClass            *bool
+    mapstruct           *bool
+    generationGap       *bool  // 是否生成基类和扩展类
+    dateTime            string // 日期时间类型的映射策略: legacy, java-time, java-time-offset
+    entityStyle         string // 实体类风格: plain, lombok, record
+    language            string // 生成的语言: java, kotlin
+    flavor              string // 生成的代码风格: mybatis, mybatis-plus, mybatis-dynamic-sql
+    mapperMode          string // mapper 的 sql 写法: xml, annotation
+    logicDeleteColumn   string
+    versionColumn       string
+    lombokAnnotations   []string
 )
 
 type Config struct {
-    Host              string   `yaml:"host"`
-    Port              uint16   `yaml:"port"`
-    User              string   `yaml:"user"`
-    Password          string   `yaml:"password"`
-    DatabaseName      string   `yaml:"database"`
-    TableNames        []string `yaml:"tables"`
-    TablePrefixs      []string `yaml:"table-prefix"`
-    RootPath          string   `yaml:"root-path"`           // 导出的根目录
-    RootPackage       string   `yaml:"root-package"`        // 导入文件的根包名
-    EntityPackage     string   `yaml:"entity-package"`      // 实体类的包名, 不包含根包名
-    MapperPackage     string   `yaml:"mapper-package"`      // mapper的包名, 不包含根包名
-    MapperXmlPath     string   `yaml:"mapper-xml-path"`     // mapper xml的路径, 不包含根包名
-    QueryPackage      string   `yaml:"query-package"`       // query的包名, 不包含根包名
-    EntityTemplate    string   `yaml:"entity-template"`     // 实体类模板
-    MapperTemplate    string   `yaml:"mapper-template"`     // mapper模板
-    MapperXmlTemplate string   `yaml:"mapper-xml-template"` // mapper xml模板
-    QueryTemplate     string   `yaml:"query-template"`      // query模板
-}
-
-type table struct {
-    TableName string
-    Comment   string
+    Driver              string        `yaml:"driver"`      // 数据库驱动: mysql, postgres, sqlite, 默认为 mysql, sqlite 时 database 为数据库文件路径
+    Schema              string        `yaml:"schema"`      // PostgreSQL 的 schema, 默认为 public
+    Ddl                 string        `yaml:"ddl"`         // MySQL DDL 文件路径, 提供时从 CREATE TABLE 语句读取表结构
+    SchemaFile          string        `yaml:"schema-file"` // inspect 导出的表结构快照, 提供时从快照读取表结构
+    Host                string        `yaml:"host"`
+    Port                uint16        `yaml:"port"`
+    User                string        `yaml:"user"`
+    Password            string        `yaml:"password"`
+    DatabaseName        string        `yaml:"database"`
+    TableNames          []string      `yaml:"tables"`
+    TablePrefixs        []string      `yaml:"table-prefix"`
+    RootPath            string        `yaml:"root-path"`             // 导出的根目录
+    RootPackage         string        `yaml:"root-package"`          // 导入文件的根包名
+    EntityPackage       string        `yaml:"entity-package"`        // 实体类的包名, 不包含根包名
+    MapperPackage       string        `yaml:"mapper-package"`        // mapper的包名, 不包含根包名
+    MapperXmlPath       string        `yaml:"mapper-xml-path"`       // mapper xml的路径, 不包含根包名
+    QueryPackage        string        `yaml:"query-package"`         // query的包名, 不包含根包名
+    ServicePackage      string        `yaml:"service-package"`       // service的包名, 不包含根包名, 为空时不生成 service 和 controller
+    ServiceImplPackage  string        `yaml:"service-impl-package"`  // service实现类的包名, 不包含根包名, 默认为 service 包下的 impl
+    ControllerPackage   string        `yaml:"controller-package"`    // controller的包名, 不包含根包名, 为空时不生成 controller
+    DtoPackage          string        `yaml:"dto-package"`           // CreateRequest, UpdateRequest, VO 的包名, 不包含根包名, 为空时不生成
+    EntityTemplate      string        `yaml:"entity-template"`       // 实体类模板
+    MapperTemplate      string        `yaml:"mapper-template"`       // mapper模板
+    MapperXmlTemplate   string        `yaml:"mapper-xml-template"`   // mapper xml模板
+    QueryTemplate       string        `yaml:"query-template"`        // query模板
+    KeyClass            bool          `yaml:"key-class"`             // 联合主键时是否生成主键类
+    KeyTemplate         string        `yaml:"key-template"`          // 主键类模板
+    ServiceTemplate     string        `yaml:"service-template"`      // service模板
+    ServiceImplTemplate string        `yaml:"service-impl-template"` // service实现类模板
+    ControllerTemplate  string        `yaml:"controller-template"`   // controller模板
+    DtoTemplate         string        `yaml:"dto-template"`          // CreateRequest, UpdateRequest, VO 共用的模板, 通过 DtoClass 区分
+    DtoExclude          DtoExclude    `yaml:"dto-exclude"`           // 各个 DTO 中排除的列
+    Mapstruct           bool          `yaml:"mapstruct"`             // 是否生成 MapStruct 转换器
+    GenerationGap       bool          `yaml:"generation-gap"`        // 是否生成总是覆盖的基类(XxxBaseEntity, XxxBaseMapper, BaseXxxMapper.xml)和仅创建一次的扩展类
+    TypeMapping         []TypeMapping `yaml:"type-mapping"`          // 类型映射, 优先于内置映射
+    DateTime            string        `yaml:"date-time"`             // 日期时间类型的映射策略: legacy(java.sql.*), java-time(LocalDateTime 等), java-time-offset(OffsetDateTime 等), 默认为 legacy
+    Language            string        `yaml:"language"`              // 生成的语言: java, kotlin, 默认为 java
+    Flavor              string        `yaml:"flavor"`                // 生成的代码风格: mybatis, mybatis-plus, mybatis-dynamic-sql, 默认为 mybatis
+    MapperMode          string        `yaml:"mapper-mode"`           // mapper 的 sql 写法: xml, annotation(不生成 mapper xml), 默认为 xml
+    LogicDeleteColumn   string        `yaml:"logic-delete-column"`   // 逻辑删除列, mybatis-plus 中使用 @TableLogic, 默认为 deleted
+    VersionColumn       string        `yaml:"version-column"`        // 乐观锁版本列, mybatis-plus 中使用 @Version, 默认为 version
+    EntityStyle         string        `yaml:"entity-style"`          // 实体类风格: plain(getter/setter), lombok, record(Java 16+), 默认为 plain
+    LombokAnnotations   []string      `yaml:"lombok-annotations"`    // lombok 风格使用的注解, 默认为 Data, Builder, NoArgsConstructor, AllArgsConstructor
 }
 
 type column struct {
-    Field     string
-    DataType  string
-    Index     string
-    Comment   string
-    IsPk      int
-    IsIndex   int
-    Property  string
-    PropertyN string
-    JdbcType  string
-    JavaType  string
+    Field           string
+    DataType        string
+    ColumnType      string // 完整类型, 如 int(10) unsigned, enum('a','b')
+    Index           string
+    Comment         string
+    Default         string
+    Extra           string
+    Length          int64 // 字符类型的最大长度
+    Precision       int64
+    Scale           int64
+    Position        int
+    EnumValues      []string
+    HasDefault      int
+    IsNullable      int
+    IsUnsigned      int
+    IsAutoIncrement int
+    IsGenerated     int // 生成列, 不能插入和更新
+    IsOnUpdate      int // on update CURRENT_TIMESTAMP
+    IsPk            int
+    IsIndex         int
+    Property        string
+    PropertyN       string
+    JdbcType        string
+    JavaType        string
+    Imports         []string // JavaType 需要的 import
+    FullJavaType    string   // JavaType 的全限定类名
+    KotlinType      string   // Kotlin 类型, 不包含 ?
+    KotlinNullable  int      // Kotlin 中是否使用可空类型
+    KotlinDefault   string   // Kotlin 属性的默认值
+    IsLogicDelete   int      // 逻辑删除列, mybatis-plus 中使用 @TableLogic
+    IsVersion       int      // 乐观锁版本列, mybatis-plus 中使用 @Version
+}
+
+type index struct {
+    Name      string
+    IsUnique  int
+    IsPrimary int
+    Fields    []column // 索引列, 按索引内的顺序排列
+    Finder    string   // 查询方法名后缀, 如 ByTenantIdAndCode
 }
 
 type TemplateData struct {
-    Pk               string
-    PkHump           string
-    PkType           string
-    PackagePath      string
-    TableNote        string
-    TableName        string
-    TableNameHump    string
-    EntityPackage    string
-    QueryPackage     string
-    QueryRootPackage string
-    MapperPackage    string
-    Fields           []column
+    Driver             string
+    Pks                []column   // 主键列, 按主键内的顺序排列
+    KeyClass           int        // 是否使用主键类作为参数, 仅联合主键时为 1
+    Indexes            []index    // 全部索引, 包含主键
+    UniqueIndexes      []index    // 主键以外的唯一索引, 用于生成 getByXxx
+    ListIndexes        []index    // 用于生成 listByXxx: 索引的首列(不包含单独构成唯一索引的列)以及外键列
+    Associations       []relation // 本表引用的其他表(多对一)
+    Collections        []relation // 引用本表的其他表(一对多)
+    Pk                 string
+    PkHump             string
+    PkType             string
+    PackagePath        string
+    TableNote          string
+    TableName          string
+    TableNameHump      string
+    EntityPackage      string
+    QueryPackage       string
+    QueryRootPackage   string
+    MapperPackage      string
+    ServicePackage     string
+    ServiceImplPackage string
+    ControllerPackage  string
+    DtoPackage         string
+    DtoClass           string   // 当前生成的 DTO 类名后缀: CreateRequest, UpdateRequest, VO
+    DtoFields          []column // 当前生成的 DTO 包含的列
+    Converter          int      // 是否生成 MapStruct 转换器
+    GenerationGap      int      // 是否生成基类, 为 1 时实体类和 mapper 生成为 XxxBaseEntity, XxxBaseMapper
+    Fields             []column
+    Imports            []string // 实体类需要的 import
+    Language           string
+    Flavor             string
+    HasLogicDelete     int // 是否有逻辑删除列
+    HasVersion         int // 是否有乐观锁版本列
+    UseGeneratedKeys   int // 是否使用自增主键回填, 仅单列自增主键且不是 record 时为 1
+    EntityStyle        string
+    EntityAnnotations  []annotation // 实体类上的注解
 }
 
 // rootCmd represents the base command when called without any subcommands
@@ -131,6 +229,7 @@ examples and usage of using your application. For example:
 Cobra is a CLI library for Go that empowers applications.
 This application is a tool to generate the needed files
 to quickly create a Cobra application.`,
+    Args: cobra.ArbitraryArgs, // [database] [tables...], 有子命令时 cobra 默认会把它们当作未知的子命令
     // Uncomment the following line if your bare application
     // has an action associated with it:
     PreRunE: func(cmd *cobra.Command, args []string) error {
@@ -159,17 +258,10 @@ to quickly create a Cobra application.`,
                 }
             }
             // 写入模板文件
-            if err := os.WriteFile(filepath.Join(generateTemplate, filepath.Join("template", "entity.ftl")), []byte(config.EntityTemp), 0750); nil != err {
-                return err
-            }
-            if err := os.WriteFile(filepath.Join(generateTemplate, filepath.Join("template", "mapper.ftl")), []byte(config.MapperTemp), 0750); nil != err {
-                return err
-            }
-            if err := os.WriteFile(filepath.Join(generateTemplate, filepath.Join("template", "mapperXml.ftl")), []byte(config.MapperXmlTemp), 0750); nil != err {
-                return err
-            }
-            if err := os.WriteFile(filepath.Join(generateTemplate, filepath.Join("template", "query.ftl")), []byte(config.QueryTempNew), 0750); nil != err {
-                return err
+            for _, v := range templateFiles {
+                if err := os.WriteFile(filepath.Join(generateTemplate, filepath.Join("template", v.name)), []byte(v.content), 0750); nil != err {
+                    return err
+                }
             }
             if err := os.WriteFile(filepath.Join(generateTemplate, "config.yaml"), []byte(config.ConfigTemp), 0750); nil != err {
                 return err
@@ -177,278 +269,31 @@ to quickly create a Cobra application.`,
             return nil
         }
 
-        if "" != configPath { // 有配置文件存在, 读取配置文件
-            data, err := os.ReadFile(configPath)
-            if nil == err { // 无错误往下执行
-                // 更换work dir
-                if err := os.Chdir(filepath.Dir(configPath)); nil != err {
-                    color.Red("Error: Change work dir failed, err: %v\n", err)
-                }
-                var config Config
-                err = yaml.Unmarshal(data, &config)
-                if err == nil { // 无错误往下执行
-                    if "" != config.Host {
-                        host = config.Host
-                    }
-                    if 0 < config.Port {
-                        *port = config.Port
-                    }
-                    if "" != config.User {
-                        user = config.User
-                    }
-                    if "" != config.Password {
-                        password = config.Password
-                    }
-                    if "" != config.DatabaseName {
-                        databaseName = config.DatabaseName
-                    }
-                    if nil != config.TableNames && 0 < len(config.TableNames) {
-                        tableNames = config.TableNames
-                    }
-                    if nil != config.TablePrefixs && 0 < len(config.TablePrefixs) {
-                        tablePrefixs = config.TablePrefixs
-                    }
-                    if "" != config.RootPath {
-                        rootPath = config.RootPath
-                    }
-                    if "" != config.RootPackage {
-                        rootPackagePath = config.RootPackage
-                    }
-                    if "" != config.EntityPackage {
-                        entityPackage = config.EntityPackage
-                    }
-                    if "" != config.MapperPackage {
-                        mapperPackage = config.MapperPackage
-                    }
-                    if "" != config.MapperXmlPath {
-                        mapperXmlPath = config.MapperXmlPath
-                    }
-                    if "" != config.QueryPackage {
-                        queryPackage = config.QueryPackage
-                    }
-                    if "" != config.EntityTemplate {
-                        fullPath, err := filepath.Abs(config.EntityTemplate)
-                        if nil != err {
-                            color.Yellow("Entity template path is not valid, use default template\n")
-                            entityTemplate = ""
-                        } else {
-                            entityTemplate = fullPath
-                        }
-                    }
-                    if "" != config.MapperTemplate {
-                        fullPath, err := filepath.Abs(config.MapperTemplate)
-                        if nil != err {
-                            color.Yellow("Mapper template path is not valid, use default template\n")
-                            mapperTemplate = ""
-                        } else {
-                            mapperTemplate = fullPath
-                        }
-                    }
-                    if "" != config.MapperXmlTemplate {
-                        fullPath, err := filepath.Abs(config.MapperXmlTemplate)
-                        if nil != err {
-                            color.Yellow("Mapper xml template path is not valid, use default template\n")
-                            mapperXmlTemplate = ""
-                        } else {
-                            mapperXmlTemplate = fullPath
-                        }
-                        //mapperXmlTemplate = config.MapperXmlTemplate
-                    }
-                    if "" != config.QueryTemplate {
-                        fullPath, err := filepath.Abs(config.QueryTemplate)
-                        if nil != err {
-                            color.Yellow("Query template path is not valid, use default template\n")
-                            queryTemplate = ""
-                        } else {
-                            queryTemplate = fullPath
-                        }
-                        //queryTemplate = config.QueryTemplate
-                    }
-                }
-            }
-        }
-
-        if "" == host {
-            host = interact.AskDBHost()
-        }
-        if 0 == *port {
-            *port = interact.AskDBPort()
-        }
-        if "" == user {
-            user = interact.AskDBUser()
-        }
-        if "" == password {
-            password = interact.AskDBPassword()
-        }
-        if rootPackagePath == "" {
-            rootPackagePath = interact.AskPackage()
-        }
-        if "" == entityPackage {
-            entityPackage = interact.AskEntityPackage()
-        }
-        if "" == mapperPackage {
-            mapperPackage = interact.AskMapperPackage()
-        }
-        if "" == mapperXmlPath {
-            mapperXmlPath = interact.AskMapperXmlPath()
-        }
-        if "" == queryPackage {
-            queryPackage = interact.AskQueryPackage()
-        }
-        if index := strings.LastIndex(queryPackage, "."); -1 == index {
-            queryRootPackage = queryPackage
-        } else {
-            queryRootPackage = queryPackage[0:index]
-        }
-        if "" == rootPath {
-            rootPath = interact.AskExportPath()
-        }
-        if "" == databaseName && nil != args && 1 <= len(args) {
-            databaseName = strings.Trim(args[0], "\"' \t\n")
-        }
-        if nil == tableNames && nil != args && 1 < len(args) {
-            for i, v := range args {
-                if 0 == i {
-                    continue
-                }
-                tableNames[i-1] = strings.Trim(v, "\"' \t\n")
-            }
-        }
-
-        if databaseName == "" {
-            // fmt.Printf("Database name can not be null")
-            databaseName = interact.AskDBName()
-        }
-        if nil == tablePrefixs {
-            if tablePrefixListStr == "" { // 说明没通过参数提供
-                tablePrefixs = interact.AskTablePrefixs()
-            } else {
-                tablePrefixs = strings.Split(strings.Trim(tablePrefixListStr, "\"' \t\n"), ",")
-            }
-        }
-
-        if *allTable {
-            tableNames = nil
-        } else {
-            if nil == tableNames || 0 == len(tableNames) { // 未填写tableNames的情况下
-                isAllTable := interact.AskIsAllTableOfDB()
-                if isAllTable {
-                    tableNames = nil
-                } else {
-                    tableNames = interact.AskTables()
-                }
-            }
+        if err := prepareExport(args); nil != err {
+            return err
         }
         if *overwriteAll {
             conflictOverwriteAll = true
         }
+        if *dryRun {
+            renderOnly = true
+        }
 
         return nil
     },
     Run: func(cmd *cobra.Command, args []string) {
-        var err error
         if "" != generateTemplate {
             color.Green("Generate template success, path: %s\n", generateTemplate)
             return
         }
-        dir, err := os.Getwd()
-        if nil != err {
-            color.Red("Get current work dir failed, err: %v\n", err)
-            return
-        }
-
-        if err = os.Chdir(dir); nil != err {
-            color.Red("Change work dir failed, err: %v\n", err)
-            return
-        }
-        if !filepath.IsAbs(rootPath) {
-            rootPath, err = filepath.Abs(rootPath)
-            if nil != err {
-                color.Red("Get absolute path of %s failed, err: %v\n", rootPath, err)
-                return
-            }
-        }
-
-        dsn := fmt.Sprintf("%s:%s@%s(%s:%d)/%s?parseTime=1&multiStatements=1&charset=utf8mb4&collation=utf8mb4_unicode_ci", user, password, "tcp", host, *port, "information_schema")
-
-        config.DbIns, err = sql.Open("mysql", dsn)
-        if nil != err {
-            color.Red("Open mysql failed, err: %v\n", err)
-            return
-        }
-        defer config.DbIns.Close()
-        //最大连接周期，超过时间的连接就close
-        config.DbIns.SetConnMaxLifetime(100 * time.Second)
-        //设置最大连接数
-        config.DbIns.SetMaxOpenConns(100)
-        //设置闲置连接数
-        config.DbIns.SetMaxIdleConns(16)
-
-        //if err = config.DbIns.Ping(); nil != err {
-        //    return errors.New(fmt.Sprintf("Connect to mysql faild, err: %v", err))
-        //}
-        // 查询出所有的表
-        var rows *sql.Rows
-        if nil != tableNames && 0 < len(tableNames) {
-            for i, v := range tableNames {
-                tableNames[i] = strings.Trim(v, "\"' \t\n")
-            }
-            // params := make([]string, len(tableNames)+1)
-            params := []interface{}{databaseName}
-
-            for _, v := range tableNames {
-                params = append(params, v)
-            }
-            rows, err = config.DbIns.Query("select TABLE_NAME as TableName, TABLE_COMMENT as `Comment` from TABLES where TABLE_SCHEMA = ? and TABLE_NAME in (?"+strings.Repeat(",?", len(tableNames)-1)+")", params...)
-            if nil != err {
-                color.Red("Error: Query all table of %s failed. err: %v\n", databaseName, err)
-                config.DbIns.Close()
-                os.Exit(-1)
-            }
-        } else {
-            rows, err = config.DbIns.Query("select TABLE_NAME as TableName, TABLE_COMMENT as `Comment` from TABLES where TABLE_SCHEMA = ?", databaseName)
-            if nil != err {
-                color.Red("Error: Query all table of %s failed. err: %v\n", databaseName, err)
-                config.DbIns.Close()
-                os.Exit(-1)
-            }
+        if err := exportTables(); nil != err {
+            color.Red("%v\n", err)
+            os.Exit(1)
         }
-        defer rows.Close()
-        // 初始化query
-        var templateData TemplateData
-        templateData.EntityPackage = entityPackage
-        templateData.QueryPackage = queryPackage
-        templateData.MapperPackage = mapperPackage
-        templateData.QueryRootPackage = queryRootPackage
-        templateData.PackagePath = rootPackagePath
-        templateData.TableNameHump = "Query"
-        //if err := generate("", config.BaseQueryTemp, queryRootPackage, "java", &templateData); nil != err {
-        //    color.Red("Generate base query[%s.%s.Query] failed, err: %s\n", rootPackagePath, queryRootPackage, err.Error())
-        //} else {
-        //    color.Green("Generate base query[%s.%s.Query] success.", rootPackagePath, queryRootPackage)
-        //}
-        for rows.Next() {
-            var tableName table
-            if err := rows.Scan(&tableName.TableName, &tableName.Comment); nil != err {
-                fmt.Printf("Scan rows failed, err: %v\n", err)
-                return
-            }
-            var templateData TemplateData
-            templateData.TableName = tableName.TableName
-            templateData.EntityPackage = entityPackage
-            templateData.QueryPackage = queryPackage
-            templateData.MapperPackage = mapperPackage
-            templateData.QueryRootPackage = queryRootPackage
-            templateData.TableNameHump = toHump(tableName.TableName, true)
-            for _, v := range tablePrefixs {
-                if strings.HasPrefix(tableName.TableName, v) {
-                    templateData.TableNameHump = toHump(strings.TrimPrefix(tableName.TableName, v), true)
-                    break
-                }
-            }
-            templateData.TableNote = tableName.Comment
-            templateData.PackagePath = rootPackagePath
-            generateTable(&templateData)
+        if *dryRun {
+            summary := renderSummary()
+            fmt.Printf("Dry run: %d to create, %d to change, %d unchanged, %d skipped, %d modified by hand, %d orphaned.\n",
+                summary[fileCreated], summary[fileChanged], summary[fileUnchanged], summary[fileSkipped], summary[fileModified], summary[fileOrphan])
         }
     },
 }
@@ -479,6 +324,10 @@ func init() {
     //}
     //defaultDocumentRoot := fmt.Sprintf("%s%cDocuments%cexports", current.HomeDir, filepath.Separator, filepath.Separator)
     isHelp = rootCmd.PersistentFlags().BoolP("help", "", false, "Help for this command")
+    rootCmd.PersistentFlags().StringVar(&driver, "driver", "", "The driver of database, mysql, postgres or sqlite, the default is mysql")
+    rootCmd.PersistentFlags().StringVar(&schemaName, "schema", "", "The schema of postgres, the default is public")
+    rootCmd.PersistentFlags().StringVar(&ddlPath, "ddl", "", "The path of mysql ddl file, generate from the CREATE TABLE statements without connecting to database")
+    rootCmd.PersistentFlags().StringVar(&schemaFile, "schema-file", "", "The path of schema snapshot file exported by inspect, generate from it without connecting to database")
     rootCmd.PersistentFlags().StringVarP(&host, "host", "h", "", "The host of mysql")
     port = rootCmd.PersistentFlags().Uint16P("port", "P", 0, "The port of mysql")
     rootCmd.PersistentFlags().StringVarP(&user, "user", "u", "", "The username of mysql")
@@ -486,208 +335,913 @@ func init() {
     rootCmd.PersistentFlags().StringVarP(&mapperPackage, "mapper-package", "m", "", "The package of the mapper that needs to be generated, not including the root package")
     rootCmd.PersistentFlags().StringVarP(&mapperXmlPath, "mapper-path", "M", "", "The path of the mapper xml that needs to be generated, not including the root package")
     rootCmd.PersistentFlags().StringVarP(&queryPackage, "query-package", "q", "", "The package of the query that needs to be generated, not including the root package")
+    rootCmd.PersistentFlags().StringVar(&servicePackage, "service-package", "", "The package of the service that needs to be generated, not including the root package, skip the service and controller if it is empty")
+    rootCmd.PersistentFlags().StringVar(&serviceImplPackage, "service-impl-package", "", "The package of the service implementation, not including the root package, the default is the impl package under the service package")
+    rootCmd.PersistentFlags().StringVar(&controllerPackage, "controller-package", "", "The package of the controller that needs to be generated, not including the root package, skip the controller if it is empty")
+    rootCmd.PersistentFlags().StringVar(&dtoPackage, "dto-package", "", "The package of the create request, update request and vo, not including the root package, skip them if it is empty")
+    mapstruct = rootCmd.PersistentFlags().Bool("mapstruct", false, "generate a mapstruct converter between entity and dto")
+    generationGap = rootCmd.PersistentFlags().Bool("generation-gap", false, "generate the base entity and mapper which are always overwritten, and the extension classes only if they don't exist")
     rootCmd.PersistentFlags().StringVarP(&password, "password", "p", "", "the password of mysql")
     rootCmd.PersistentFlags().StringVar(&rootPath, "root-path", "", "the path of export directory")
     rootCmd.PersistentFlags().StringVar(&rootPackagePath, "package", "", "the package path of generate, e.g: \"work.bottle\"")
     rootCmd.PersistentFlags().StringVar(&tablePrefixListStr, "table-prefix", "", "the table prefix of table name, How to have multiple values, please use \",\" to separate")
     overwriteAll = rootCmd.PersistentFlags().BoolP("overwrite", "o", false, "overwrite all of exists files")
+    prune = rootCmd.PersistentFlags().Bool("prune", false, "delete the generated files whose table no longer exists")
+    force = rootCmd.PersistentFlags().Bool("force", false, "overwrite the generated files even if they have been modified by hand")
+    dryRun = rootCmd.PersistentFlags().Bool("dry-run", false, "render all files in memory and print what would be created or changed with a diff, without writing")
     allTable = rootCmd.PersistentFlags().BoolP("all-table", "a", false, "generator all of table")
+    rootCmd.PersistentFlags().StringVar(&dateTime, "date-time", "", "The mapping of date and time types, legacy, java-time or java-time-offset, the default is legacy")
+    rootCmd.PersistentFlags().StringVar(&language, "language", "", "The language of generated sources, java or kotlin, the default is java")
+    rootCmd.PersistentFlags().StringVar(&flavor, "flavor", "", "The flavor of generated code, mybatis, mybatis-plus or mybatis-dynamic-sql, the default is mybatis")
+    rootCmd.PersistentFlags().StringVar(&mapperMode, "mapper-mode", "", "The way of writing sql in mapper, xml or annotation, the default is xml")
+    rootCmd.PersistentFlags().StringVar(&entityStyle, "entity-style", "", "The style of entity, plain, lombok or record, the default is plain")
+    keyClass = rootCmd.PersistentFlags().Bool("key-class", false, "generate a key class for the table with composite primary key")
 
     rootCmd.PersistentFlags().StringVarP(&generateTemplate, "generate-template", "g", "", "generate templates path")
     rootCmd.PersistentFlags().StringVarP(&configPath, "config", "c", "", "config file path")
 }
 
-func generateTable(temp *TemplateData) {
+// templateFiles -g 导出的内置模板, 生成清单中用文件名标识生成文件所用的模板
+var templateFiles = []struct {
+    name    string
+    content string
+}{
+    {"entity.ftl", config.EntityTemp},
+    {"mapper.ftl", config.MapperTemp},
+    {"mapperXml.ftl", config.MapperXmlTemp},
+    {"query.ftl", config.QueryTempNew},
+    {"key.ftl", config.KeyTemp},
+    {"entity.kt.ftl", config.KotlinEntityTemp},
+    {"mapper.kt.ftl", config.KotlinMapperTemp},
+    {"query.kt.ftl", config.KotlinQueryTemp},
+    {"key.kt.ftl", config.KotlinKeyTemp},
+    {"entity.plus.ftl", config.PlusEntityTemp},
+    {"mapper.plus.ftl", config.PlusMapperTemp},
+    {"mapperXml.plus.ftl", config.PlusMapperXmlTemp},
+    {"entity.plus.kt.ftl", config.KotlinPlusEntityTemp},
+    {"mapper.plus.kt.ftl", config.KotlinPlusMapperTemp},
+    {"mapper.dynamic.ftl", config.DynamicSqlMapperTemp},
+    {"support.dynamic.ftl", config.DynamicSqlSupportTemp},
+    {"service.ftl", config.ServiceTemp},
+    {"serviceImpl.ftl", config.ServiceImplTemp},
+    {"controller.ftl", config.ControllerTemp},
+    {"service.kt.ftl", config.KotlinServiceTemp},
+    {"serviceImpl.kt.ftl", config.KotlinServiceImplTemp},
+    {"controller.kt.ftl", config.KotlinControllerTemp},
+    {"dto.ftl", config.DtoTemp},
+    {"converter.ftl", config.ConverterTemp},
+    {"dto.kt.ftl", config.KotlinDtoTemp},
+    {"converter.kt.ftl", config.KotlinConverterTemp},
+    {"entity.gap.ftl", config.GapEntityTemp},
+    {"mapper.gap.ftl", config.GapMapperTemp},
+    {"mapperXml.gap.ftl", config.GapMapperXmlTemp},
+    {"mapper.annotation.ftl", config.AnnotationMapperTemp},
+}
+
+// loadConfigFile 读取配置文件, 命令行未提供的参数使用配置文件中的值
+func loadConfigFile() {
+    if "" != configPath { // 有配置文件存在, 读取配置文件
+        data, err := os.ReadFile(configPath)
+        if nil == err { // 无错误往下执行
+            // 更换work dir
+            if err := os.Chdir(filepath.Dir(configPath)); nil != err {
+                color.Red("Error: Change work dir failed, err: %v\n", err)
+            }
+            var config Config
+            err = yaml.Unmarshal(data, &config)
+            if err == nil { // 无错误往下执行
+                if "" != config.Driver && "" == driver {
+                    driver = config.Driver
+                }
+                if "" != config.Schema && "" == schemaName {
+                    schemaName = config.Schema
+                }
+                if "" != config.Ddl && "" == ddlPath {
+                    ddlPath = config.Ddl
+                }
+                if "" != config.SchemaFile && "" == schemaFile {
+                    schemaFile = config.SchemaFile
+                }
+                if "" != config.Host && "" == host {
+                    host = config.Host
+                }
+                if 0 < config.Port && 0 == *port {
+                    *port = config.Port
+                }
+                if "" != config.User && "" == user {
+                    user = config.User
+                }
+                if "" != config.Password && "" == password {
+                    password = config.Password
+                }
+                if "" != config.DatabaseName {
+                    databaseName = config.DatabaseName
+                }
+                if nil != config.TableNames && 0 < len(config.TableNames) {
+                    tableNames = config.TableNames
+                }
+                if nil != config.TablePrefixs && 0 < len(config.TablePrefixs) && "" == tablePrefixListStr {
+                    tablePrefixs = config.TablePrefixs
+                }
+                if "" != config.RootPath && "" == rootPath {
+                    rootPath = config.RootPath
+                }
+                if "" != config.RootPackage && "" == rootPackagePath {
+                    rootPackagePath = config.RootPackage
+                }
+                if "" != config.EntityPackage && "" == entityPackage {
+                    entityPackage = config.EntityPackage
+                }
+                if "" != config.MapperPackage && "" == mapperPackage {
+                    mapperPackage = config.MapperPackage
+                }
+                if "" != config.ServicePackage && "" == servicePackage {
+                    servicePackage = config.ServicePackage
+                }
+                if "" != config.ServiceImplPackage && "" == serviceImplPackage {
+                    serviceImplPackage = config.ServiceImplPackage
+                }
+                if "" != config.ControllerPackage && "" == controllerPackage {
+                    controllerPackage = config.ControllerPackage
+                }
+                if "" != config.DtoPackage && "" == dtoPackage {
+                    dtoPackage = config.DtoPackage
+                }
+                if config.Mapstruct {
+                    *mapstruct = true
+                }
+                if config.GenerationGap {
+                    *generationGap = true
+                }
+                if 0 < len(config.DtoExclude.Create) {
+                    dtoExclude.Create = config.DtoExclude.Create
+                }
+                if 0 < len(config.DtoExclude.Update) {
+                    dtoExclude.Update = config.DtoExclude.Update
+                }
+                if 0 < len(config.DtoExclude.Vo) {
+                    dtoExclude.Vo = config.DtoExclude.Vo
+                }
+                if "" != config.MapperXmlPath && "" == mapperXmlPath {
+                    mapperXmlPath = config.MapperXmlPath
+                }
+                if "" != config.QueryPackage && "" == queryPackage {
+                    queryPackage = config.QueryPackage
+                }
+                if "" != config.EntityTemplate {
+                    fullPath, err := filepath.Abs(config.EntityTemplate)
+                    if nil != err {
+                        color.Yellow("Entity template path is not valid, use default template\n")
+                        entityTemplate = ""
+                    } else {
+                        entityTemplate = fullPath
+                    }
+                }
+                if "" != config.MapperTemplate {
+                    fullPath, err := filepath.Abs(config.MapperTemplate)
+                    if nil != err {
+                        color.Yellow("Mapper template path is not valid, use default template\n")
+                        mapperTemplate = ""
+                    } else {
+                        mapperTemplate = fullPath
+                    }
+                }
+                if "" != config.MapperXmlTemplate {
+                    fullPath, err := filepath.Abs(config.MapperXmlTemplate)
+                    if nil != err {
+                        color.Yellow("Mapper xml template path is not valid, use default template\n")
+                        mapperXmlTemplate = ""
+                    } else {
+                        mapperXmlTemplate = fullPath
+                    }
+                    //mapperXmlTemplate = config.MapperXmlTemplate
+                }
+                if config.KeyClass {
+                    *keyClass = true
+                }
+                if "" != config.KeyTemplate {
+                    fullPath, err := filepath.Abs(config.KeyTemplate)
+                    if nil != err {
+                        color.Yellow("Key template path is not valid, use default template\n")
+                        keyTemplate = ""
+                    } else {
+                        keyTemplate = fullPath
+                    }
+                }
+                if "" != config.ServiceTemplate {
+                    fullPath, err := filepath.Abs(config.ServiceTemplate)
+                    if nil != err {
+                        color.Yellow("Service template path is not valid, use default template\n")
+                        serviceTemplate = ""
+                    } else {
+                        serviceTemplate = fullPath
+                    }
+                }
+                if "" != config.ServiceImplTemplate {
+                    fullPath, err := filepath.Abs(config.ServiceImplTemplate)
+                    if nil != err {
+                        color.Yellow("Service impl template path is not valid, use default template\n")
+                        serviceImplTemplate = ""
+                    } else {
+                        serviceImplTemplate = fullPath
+                    }
+                }
+                if "" != config.ControllerTemplate {
+                    fullPath, err := filepath.Abs(config.ControllerTemplate)
+                    if nil != err {
+                        color.Yellow("Controller template path is not valid, use default template\n")
+                        controllerTemplate = ""
+                    } else {
+                        controllerTemplate = fullPath
+                    }
+                }
+                if "" != config.DtoTemplate {
+                    fullPath, err := filepath.Abs(config.DtoTemplate)
+                    if nil != err {
+                        color.Yellow("Dto template path is not valid, use default template\n")
+                        dtoTemplate = ""
+                    } else {
+                        dtoTemplate = fullPath
+                    }
+                }
+                if "" != config.DateTime && "" == dateTime {
+                    dateTime = config.DateTime
+                }
+                if "" != config.Language && "" == language {
+                    language = config.Language
+                }
+                if "" != config.Flavor && "" == flavor {
+                    flavor = config.Flavor
+                }
+                if "" != config.MapperMode && "" == mapperMode {
+                    mapperMode = config.MapperMode
+                }
+                if "" != config.LogicDeleteColumn {
+                    logicDeleteColumn = config.LogicDeleteColumn
+                }
+                if "" != config.VersionColumn {
+                    versionColumn = config.VersionColumn
+                }
+                if "" != config.EntityStyle && "" == entityStyle {
+                    entityStyle = config.EntityStyle
+                }
+                if 0 < len(config.LombokAnnotations) {
+                    lombokAnnotations = config.LombokAnnotations
+                }
+                if 0 < len(config.TypeMapping) {
+                    typeMappings = loadTypeMappings(config.TypeMapping)
+                }
+                if "" != config.QueryTemplate {
+                    fullPath, err := filepath.Abs(config.QueryTemplate)
+                    if nil != err {
+                        color.Yellow("Query template path is not valid, use default template\n")
+                        queryTemplate = ""
+                    } else {
+                        queryTemplate = fullPath
+                    }
+                    //queryTemplate = config.QueryTemplate
+                }
+            }
+        }
+    }
+}
+
+// prepareExport 读取配置并校验生成代码所需的参数, 缺少的参数通过交互补全, 非交互时记录在 missingParams 中
+func prepareExport(args []string) error {
+    loadConfigFile()
+    if err := prepareSource(args); nil != err {
+        return err
+    }
+    if "" == language {
+        language = "java"
+    }
+    if "java" != language && "kotlin" != language {
+        return errors.New("The language[" + language + "] is not supported")
+    }
+    if "" == entityStyle {
+        entityStyle = "plain"
+    }
+    if !contains(entityStyles, entityStyle) {
+        return errors.New("The entity style[" + entityStyle + "] is not supported")
+    }
+    if "" == flavor {
+        flavor = "mybatis"
+    }
+    if "mybatis" != flavor && "mybatis-plus" != flavor && "mybatis-dynamic-sql" != flavor {
+        return errors.New("The flavor[" + flavor + "] is not supported")
+    }
+    if "" == mapperMode {
+        mapperMode = "xml"
+    }
+    if "xml" != mapperMode && "annotation" != mapperMode {
+        return errors.New("The mapper mode[" + mapperMode + "] is not supported")
+    }
+    if "annotation" == mapperMode && "kotlin" == language && "mybatis" == flavor {
+        return errors.New("The mapper mode[annotation] is not supported by kotlin")
+    }
+    if "mybatis-dynamic-sql" == flavor && "kotlin" == language {
+        return errors.New("The flavor[mybatis-dynamic-sql] is not supported by kotlin")
+    }
+    if "mybatis-plus" == flavor && "record" == entityStyle {
+        return errors.New("The entity style[record] is not supported by mybatis-plus")
+    }
+    if "" == logicDeleteColumn {
+        logicDeleteColumn = "deleted"
+    }
+    if "" == versionColumn {
+        versionColumn = "version"
+    }
+    if "kotlin" == language && "plain" != entityStyle {
+        color.Yellow("The entity style[%s] is ignored, kotlin always generates data class\n", entityStyle)
+        entityStyle = "plain"
+    }
+    if nil == lombokAnnotations {
+        lombokAnnotations = defaultLombokAnnotations
+    }
+    if rootPackagePath == "" && askable("package") {
+        rootPackagePath = interact.AskPackage()
+    }
+    if "" == entityPackage && askable("entity-package") {
+        entityPackage = interact.AskEntityPackage()
+    }
+    if "" == mapperPackage && askable("mapper-package") {
+        mapperPackage = interact.AskMapperPackage()
+    }
+    if "" == mapperXmlPath && askable("mapper-path") {
+        mapperXmlPath = interact.AskMapperXmlPath()
+    }
+    if "" == queryPackage && askable("query-package") {
+        queryPackage = interact.AskQueryPackage()
+    }
+    if *generationGap && "java" != language {
+        return errors.New("The generation gap is not supported by " + language)
+    }
+    if *generationGap && "mybatis" != flavor {
+        return errors.New("The generation gap is not supported by " + flavor)
+    }
+    if *generationGap && "record" == entityStyle {
+        return errors.New("The generation gap is not supported by entity style[record]")
+    }
+    if *mapstruct && "" == dtoPackage {
+        return errors.New("The mapstruct converter depends on the dto, the dto package is required")
+    }
+    if "" != servicePackage && "" == serviceImplPackage {
+        serviceImplPackage = servicePackage + ".impl"
+    }
+    if "" == servicePackage && ("" != serviceImplPackage || "" != controllerPackage) {
+        return errors.New("The service-impl and controller depend on the service, the service package is required")
+    }
+    if "" != servicePackage && "mybatis" != flavor {
+        color.Yellow("The service and controller are only supported by flavor[mybatis], they will be skipped\n")
+        servicePackage, serviceImplPackage, controllerPackage = "", "", ""
+    }
+    if index := strings.LastIndex(queryPackage, "."); -1 == index {
+        queryRootPackage = queryPackage
+    } else {
+        queryRootPackage = queryPackage[0:index]
+    }
+    if "" == rootPath && askable("root-path") {
+        rootPath = interact.AskExportPath()
+    }
+    if nil == tablePrefixs {
+        if tablePrefixListStr == "" && nonInteractive { // 表前缀是可选的
+            tablePrefixs = []string{}
+        } else if tablePrefixListStr == "" { // 说明没通过参数提供
+            tablePrefixs = interact.AskTablePrefixs()
+        } else {
+            tablePrefixs = strings.Split(strings.Trim(tablePrefixListStr, "\"' \t\n"), ",")
+        }
+    }
+    return nil
+}
+
+// exportTables 查询所有的表并逐个生成代码
+func exportTables() error {
+    dir, err := os.Getwd()
+    if nil != err {
+        return fmt.Errorf("Get current work dir failed, err: %v", err)
+    }
+
+    if err = os.Chdir(dir); nil != err {
+        return fmt.Errorf("Change work dir failed, err: %v", err)
+    }
+    if !filepath.IsAbs(rootPath) {
+        rootPath, err = filepath.Abs(rootPath)
+        if nil != err {
+            return fmt.Errorf("Get absolute path of %s failed, err: %v", rootPath, err)
+        }
+    }
+
+    loadManifest()
+
+    provider, err := newProvider()
+    if nil != err {
+        return fmt.Errorf("Error: %v", err)
+    }
+    defer provider.Close()
+
+    // 查询出所有的表
+    for i, v := range tableNames {
+        tableNames[i] = strings.Trim(v, "\"' \t\n")
+    }
+    tables, err := provider.Tables(tableNames)
+    if nil != err {
+        return fmt.Errorf("Error: Query all table of %s failed. err: %v", databaseName, err)
+    }
+    // 初始化query
+    var templateData TemplateData
+    templateData.EntityPackage = entityPackage
+    templateData.QueryPackage = queryPackage
+    templateData.MapperPackage = mapperPackage
+    templateData.QueryRootPackage = queryRootPackage
+    templateData.PackagePath = rootPackagePath
+    templateData.TableNameHump = "Query"
+    //if err := generate("", config.BaseQueryTemp, queryRootPackage, "java", &templateData); nil != err {
+    //    color.Red("Generate base query[%s.%s.Query] failed, err: %s\n", rootPackagePath, queryRootPackage, err.Error())
+    //} else {
+    //    color.Green("Generate base query[%s.%s.Query] success.", rootPackagePath, queryRootPackage)
+    //}
+    exportedTables = map[string]bool{}
+    for _, v := range tables {
+        exportedTables[v.Name] = true
+    }
+    for _, tableName := range tables {
+        var templateData TemplateData
+        templateData.TableName = tableName.Name
+        templateData.Driver = driver
+        templateData.EntityPackage = entityPackage
+        templateData.QueryPackage = queryPackage
+        templateData.MapperPackage = mapperPackage
+        templateData.ServicePackage = servicePackage
+        templateData.ServiceImplPackage = serviceImplPackage
+        templateData.ControllerPackage = controllerPackage
+        templateData.DtoPackage = dtoPackage
+        if *mapstruct {
+            templateData.Converter = 1
+        }
+        if *generationGap {
+            templateData.GenerationGap = 1
+        }
+        templateData.QueryRootPackage = queryRootPackage
+        templateData.TableNameHump = tableNameHump(tableName.Name)
+        templateData.TableNote = tableName.Comment
+        templateData.PackagePath = rootPackagePath
+        templateData.Language = language
+        templateData.Flavor = flavor
+        templateData.EntityStyle = entityStyle
+        if "lombok" == entityStyle {
+            templateData.EntityAnnotations = parseAnnotations("lombok", lombokAnnotations)
+        }
+        generateTable(provider, &templateData)
+    }
+    if err := pruneOrphans(provider); nil != err {
+        return fmt.Errorf("Error: Prune orphaned files failed. err: %v", err)
+    }
+    if renderOnly {
+        return nil
+    }
+    if err := saveManifest(); nil != err {
+        return fmt.Errorf("Write manifest[%s] failed, err: %v", manifestName, err)
+    }
+    return nil
+}
+
+// prepareSource 准备表结构来源相关的参数: 数据库连接、库名和表名
+func prepareSource(args []string) error {
+    if "" == driver && "" == schemaFile { // 快照中记录了驱动
+        driver = "mysql"
+    }
+    if "" != driver && "mysql" != driver && "postgres" != driver && "sqlite" != driver {
+        return errors.New("The driver[" + driver + "] is not supported")
+    }
+    if _, ok := dateTimeMappings[dateTime]; "" != dateTime && !ok {
+        return errors.New("The date-time[" + dateTime + "] is not supported")
+    }
+    if "sqlite" != driver && !isOffline() { // sqlite 只需要数据库文件路径
+        if "" == host && askable("host") {
+            host = interact.AskDBHost()
+        }
+        if 0 == *port && nonInteractive { // 非交互时使用默认端口
+            *port = 3306
+            if "postgres" == driver {
+                *port = 5432
+            }
+        } else if 0 == *port {
+            if "postgres" == driver {
+                *port = interact.AskDBPort(5432)
+            } else {
+                *port = interact.AskDBPort(3306)
+            }
+        }
+        if "" == user && askable("user") {
+            user = interact.AskDBUser()
+        }
+        if "" == password && !nonInteractive { // 密码可以为空
+            password = interact.AskDBPassword()
+        }
+    }
+    if nil != args && 1 <= len(args) { // 命令行参数优先于配置文件
+        databaseName = strings.Trim(args[0], "\"' \t\n")
+    }
+    if nil != args && 1 < len(args) {
+        tableNames = nil
+        for _, v := range args[1:] {
+            tableNames = append(tableNames, strings.Trim(v, "\"' \t\n"))
+        }
+    }
+
+    if databaseName == "" && !isOffline() && askable("database") {
+        // fmt.Printf("Database name can not be null")
+        databaseName = interact.AskDBName()
+    }
+    if *allTable {
+        tableNames = nil
+    } else {
+        if (nil == tableNames || 0 == len(tableNames)) && askable("tables or all-table") { // 未填写tableNames的情况下
+            isAllTable := interact.AskIsAllTableOfDB()
+            if isAllTable {
+                tableNames = nil
+            } else {
+                tableNames = interact.AskTables()
+            }
+        }
+    }
+    return nil
+}
+
+// isOffline 是否不需要连接数据库
+func isOffline() bool {
+    return "" != ddlPath || "" != schemaFile
+}
+
+func generateTable(provider schema.Provider, temp *TemplateData) {
     // fmt.Printf("TableName is : %v, TableNameHump: %v, pointer: %p\n", temp.TableName, temp.TableNameHump, &temp)
-    rows, err := config.DbIns.Query("select `COLUMN_NAME` as Field, `DATA_TYPE` as DataType, `COLUMN_KEY` as `Index`, `COLUMN_COMMENT` as Comment from `COLUMNS` where TABLE_SCHEMA = ? AND TABLE_NAME = ?", databaseName, temp.TableName)
+    columns, err := provider.Columns(temp.TableName)
+    if nil != err {
+        color.Red("Query table %v failed, err: %v\n", temp.TableName, err)
+        exportFailed = true
+        return
+    }
+    pks, err := provider.PrimaryKeys(temp.TableName)
+    if nil != err {
+        color.Red("Query primary key of table %v failed, err: %v\n", temp.TableName, err)
+        exportFailed = true
+        return
+    }
+    indexes, err := provider.Indexes(temp.TableName)
     if nil != err {
-        fmt.Println("Query table %v failed, err: %v", temp.TableName, err)
+        color.Red("Query index of table %v failed, err: %v\n", temp.TableName, err)
+        exportFailed = true
         return
     }
-    defer rows.Close()
-    for rows.Next() {
+    sort.SliceStable(columns, func(i, j int) bool {
+        return columns[i].Position < columns[j].Position
+    })
+    for _, v := range columns {
         var column column
-        if err := rows.Scan(&column.Field, &column.DataType, &column.Index, &column.Comment); nil != err {
-            fmt.Printf("Scan rows failed, err: %v\n", err)
-            return
+        column.Field = v.Name
+        column.DataType = v.DataType
+        column.ColumnType = v.ColumnType
+        column.Index = v.Key
+        column.Comment = v.Comment
+        column.Extra = v.Extra
+        column.Length = v.MaxLength
+        column.Precision = v.Precision
+        column.Scale = v.Scale
+        column.Position = v.Position
+        column.EnumValues = enumValues(v.ColumnType)
+        if nil != v.Default {
+            column.HasDefault = 1
+            column.Default = *v.Default
+        }
+        if v.Nullable {
+            column.IsNullable = 1
+        }
+        extra := strings.ToLower(v.Extra)
+        if strings.Contains(strings.ToLower(v.ColumnType), "unsigned") {
+            column.IsUnsigned = 1
+        }
+        if strings.Contains(extra, "auto_increment") {
+            column.IsAutoIncrement = 1
+        }
+        if strings.Contains(extra, "virtual generated") || strings.Contains(extra, "stored generated") {
+            column.IsGenerated = 1
+        }
+        if strings.Contains(extra, "on update") {
+            column.IsOnUpdate = 1
         }
         column.Property = toHump(column.Field, false)
         column.PropertyN = toHump(column.Field, true)
-        if column.Index == "PRI" {
+        if contains(pks, column.Field) {
             column.IsPk = 1
-            temp.Pk = column.Field
-            temp.PkHump = column.Property
         }
         if column.Index == "PRI" || column.Index == "MUL" || column.Index == "UNI" {
             column.IsIndex = 1
         }
-        switch column.DataType {
-        case "int", "integer", "INT", "INTEGER":
-            column.JdbcType = "INTEGER"
-            column.JavaType = "Integer"
-            if column.IsPk == 1 {
-                temp.PkType = "Integer"
-            }
-        case "mediumint", "MEDIUMINT":
-            column.JdbcType = "INTEGER"
-            column.JavaType = "Integer"
-            if column.IsPk == 1 {
-                temp.PkType = "Integer"
-            }
-        case "varchar", "VARCHAR":
-            column.JdbcType = "VARCHAR"
-            column.JavaType = "String"
-            if column.IsPk == 1 {
-                temp.PkType = "String"
-            }
-        case "tinyint", "TINYINT":
-            column.JdbcType = "TINYINT"
-            column.JavaType = "Integer"
-            if column.IsPk == 1 {
-                temp.PkType = "Integer"
-            }
-        case "timestamp", "datetime", "TIMESTAMP", "DATETIME":
-            column.JdbcType = "TIMESTAMP"
-            column.JavaType = "java.sql.Timestamp"
-            if column.IsPk == 1 {
-                temp.PkType = "java.sql.Timestamp"
-            }
-        case "time", "TIME":
-            column.JdbcType = "TIME"
-            column.JavaType = "java.sql.Time"
-            if column.IsPk == 1 {
-                temp.PkType = "java.sql.Time"
-            }
-        case "smallint", "SMALLINT":
-            column.JdbcType = "SMALLINT"
-            column.JavaType = "Integer"
-            if column.IsPk == 1 {
-                temp.PkType = "Integer"
-            }
-        case "real", "REAL":
-            column.JdbcType = "REAL"
-            column.JavaType = "Object"
-            if column.IsPk == 1 {
-                temp.PkType = "Object"
-            }
-        case "numeric", "NUMERIC":
-            column.JdbcType = "NUMERIC"
-            column.JavaType = "BigDecimal"
-            if column.IsPk == 1 {
-                temp.PkType = "BigDecimal"
-            }
-        case "float", "FLOAT":
-            column.JdbcType = "FLOAT"
-            column.JavaType = "Float"
-            if column.IsPk == 1 {
-                temp.PkType = "Float"
-            }
-        case "double", "DOUBLE":
-            column.JdbcType = "DOUBLE"
-            column.JavaType = "Double"
-            if column.IsPk == 1 {
-                temp.PkType = "Double"
-            }
-        case "decimal", "DECIMAL":
-            column.JdbcType = "DECIMAL"
-            column.JavaType = "BigDecimal"
-            if column.IsPk == 1 {
-                temp.PkType = "BigDecimal"
-            }
-        case "date", "DATE":
-            column.JdbcType = "DATE"
-            column.JavaType = "java.sql.Date"
-            if column.IsPk == 1 {
-                temp.PkType = "java.sql.Date"
-            }
-        case "clob", "CLOB", "text", "TEXT":
-            column.JdbcType = "CLOB"
-            column.JavaType = "String"
-            if column.IsPk == 1 {
-                temp.PkType = "String"
-            }
-        case "char", "CHAR":
-            column.JdbcType = "CHAR"
-            column.JavaType = "String"
-            if column.IsPk == 1 {
-                temp.PkType = "String"
-            }
-        case "blob", "BLOB":
-            column.JdbcType = "BLOB"
-            column.JavaType = "Byte[]"
-            if column.IsPk == 1 {
-                temp.PkType = "Byte[]"
+        for _, idx := range indexes { // COLUMN_KEY 只标记索引的首列
+            if contains(idx.Columns, column.Field) {
+                column.IsIndex = 1
             }
-        case "bit", "BIT":
-            column.JdbcType = "BIT"
-            column.JavaType = "Byte"
-            if column.IsPk == 1 {
-                temp.PkType = "Byte"
-            }
-        case "bigint", "BIGINT":
-            column.JdbcType = "BIGINT"
-            column.JavaType = "Long"
-            if column.IsPk == 1 {
-                temp.PkType = "Long"
-            }
-        default:
-            column.JdbcType = ""
-            column.JavaType = "Object"
-            if column.IsPk == 1 {
-                temp.PkType = "Object"
+        }
+        column.JdbcType, column.JavaType, column.Imports = columnType(column.Field, column.DataType, column.ColumnType)
+        column.FullJavaType = qualifiedType(column.JavaType, column.Imports)
+        fillKotlinType(&column)
+        if strings.EqualFold(column.Field, logicDeleteColumn) {
+            column.IsLogicDelete = 1
+            temp.HasLogicDelete = 1
+        }
+        if strings.EqualFold(column.Field, versionColumn) {
+            column.IsVersion = 1
+            temp.HasVersion = 1
+        }
+        for _, v := range column.Imports {
+            if !contains(temp.Imports, v) {
+                temp.Imports = append(temp.Imports, v)
             }
         }
         temp.Fields = append(temp.Fields, column)
         // fmt.Printf("Field: %v, Property: %v, DataType: %v, Index: %v, IsIndex: %v, IsPk: %v, Comment: %v\n", column.Field, column.Property, column.DataType, column.Index, column.IsIndex, column.IsPk, column.Comment)
     }
+    sort.Strings(temp.Imports)
+    for _, pk := range pks {
+        for _, v := range temp.Fields {
+            if v.Field == pk {
+                temp.Pks = append(temp.Pks, v)
+                break
+            }
+        }
+    }
+    fillIndexes(temp, indexes)
+    if err := fillRelations(provider, temp); nil != err {
+        color.Red("Query foreign key of table %v failed, err: %v\n", temp.TableName, err)
+        exportFailed = true
+        return
+    }
+    if "record" == temp.EntityStyle { // record 不能通过 setter 填充关联对象
+        temp.Associations, temp.Collections = nil, nil
+    }
+    plus := "mybatis-plus" == temp.Flavor
+    if plus { // mybatis-plus 的 xml 只包含 resultMap, 不生成关联查询
+        temp.Associations, temp.Collections = nil, nil
+        if 1 < len(temp.Pks) {
+            color.Yellow("Table %s has composite primary key, which is not supported by mybatis-plus, @TableId will be skipped.\n", temp.TableName)
+        }
+    }
+    dynamic := "mybatis-dynamic-sql" == temp.Flavor
+    if dynamic { // mybatis-dynamic-sql 使用 DynamicSqlSupport 构建查询, 不生成关联查询
+        temp.Associations, temp.Collections = nil, nil
+    }
+    if 1 == len(temp.Pks) && 1 == temp.Pks[0].IsAutoIncrement && "record" != temp.EntityStyle { // record 不能回填主键
+        temp.UseGeneratedKeys = 1
+    }
+    if 0 == len(temp.Pks) {
+        color.Yellow("Table %s has no primary key, the statements depend on it will be skipped.\n", temp.TableName)
+    } else {
+        temp.Pk = temp.Pks[0].Field
+        temp.PkHump = temp.Pks[0].Property
+        temp.PkType = temp.Pks[0].JavaType
+    }
 
-    if err := generate("", entityTemp(), entityPackage, "java", temp); nil != err {
+    if 1 == temp.GenerationGap {
+        generateGapEntity(temp)
+    } else if err := generate("", entityTemp(), entityPackage, sourceSuffix(), temp); nil != err {
         color.Red("Generate entity[%s.%s.%s] failed, err: %s\n", temp.PackagePath, temp.EntityPackage, temp.TableNameHump, err.Error())
     } else {
-        color.Green("Generate entity[%s.%s.%s] success.", temp.PackagePath, temp.EntityPackage, temp.TableNameHump)
+        generated("Generate entity[%s.%s.%s] success.", temp.PackagePath, temp.EntityPackage, temp.TableNameHump)
     }
-    if err := generate("query", queryTemp(), queryPackage, "java", temp); nil != err {
-        color.Red("Generate query[%s.%s.%sQuery] failed, err: %s\n", temp.PackagePath, temp.QueryPackage, temp.TableNameHump, err.Error())
-    } else {
-        color.Green("Generate query[%s.%s.%sQuery] success.", temp.PackagePath, temp.QueryPackage, temp.TableNameHump)
+    if "" != temp.DtoPackage {
+        generateDtos(temp)
+    }
+    if *keyClass && 1 < len(temp.Pks) && !plus && !dynamic {
+        temp.KeyClass = 1
+        if err := generate("key", keyTemp(), entityPackage, sourceSuffix(), temp); nil != err {
+            color.Red("Generate key[%s.%s.%sKey] failed, err: %s\n", temp.PackagePath, temp.EntityPackage, temp.TableNameHump, err.Error())
+        } else {
+            generated("Generate key[%s.%s.%sKey] success.", temp.PackagePath, temp.EntityPackage, temp.TableNameHump)
+        }
     }
-    if err := generate("mapper", mapperTemp(), mapperPackage, "java", temp); nil != err {
+    if !plus && !dynamic { // mybatis-plus 使用 QueryWrapper, mybatis-dynamic-sql 使用 SqlBuilder, 不需要 query 类
+        if err := generate("query", queryTemp(), queryPackage, sourceSuffix(), temp); nil != err {
+            color.Red("Generate query[%s.%s.%sQuery] failed, err: %s\n", temp.PackagePath, temp.QueryPackage, temp.TableNameHump, err.Error())
+        } else {
+            generated("Generate query[%s.%s.%sQuery] success.", temp.PackagePath, temp.QueryPackage, temp.TableNameHump)
+        }
+    }
+    if 1 == temp.GenerationGap {
+        generateGapMapper(temp)
+    } else if err := generate("mapper", mapperTemp(), mapperPackage, sourceSuffix(), temp); nil != err {
         color.Red("Generate mapper[%s.%s.%sMapper] failed, err: %s\n", temp.PackagePath, temp.MapperPackage, temp.TableNameHump, err.Error())
     } else {
-        color.Green("Generate mapper[%s.%s.%sMapper] success.", temp.PackagePath, temp.MapperPackage, temp.TableNameHump)
+        generated("Generate mapper[%s.%s.%sMapper] success.", temp.PackagePath, temp.MapperPackage, temp.TableNameHump)
+    }
+    if "" != temp.ServicePackage {
+        if err := generateOwned("service", serviceTemp(), temp.ServicePackage, sourceSuffix(), temp); nil != err {
+            color.Red("Generate service[%s.%s.%sService] failed, err: %s\n", temp.PackagePath, temp.ServicePackage, temp.TableNameHump, err.Error())
+        } else {
+            generated("Generate service[%s.%s.%sService] success.", temp.PackagePath, temp.ServicePackage, temp.TableNameHump)
+        }
+        if err := generateOwned("service_impl", serviceImplTemp(), temp.ServiceImplPackage, sourceSuffix(), temp); nil != err {
+            color.Red("Generate service impl[%s.%s.%sServiceImpl] failed, err: %s\n", temp.PackagePath, temp.ServiceImplPackage, temp.TableNameHump, err.Error())
+        } else {
+            generated("Generate service impl[%s.%s.%sServiceImpl] success.", temp.PackagePath, temp.ServiceImplPackage, temp.TableNameHump)
+        }
+    }
+    if "" != temp.ControllerPackage {
+        if err := generateOwned("controller", controllerTemp(), temp.ControllerPackage, sourceSuffix(), temp); nil != err {
+            color.Red("Generate controller[%s.%s.%sController] failed, err: %s\n", temp.PackagePath, temp.ControllerPackage, temp.TableNameHump, err.Error())
+        } else {
+            generated("Generate controller[%s.%s.%sController] success.", temp.PackagePath, temp.ControllerPackage, temp.TableNameHump)
+        }
+    }
+    if dynamic {
+        if err := generate("dynamic_sql_support", config.DynamicSqlSupportTemp, mapperPackage, sourceSuffix(), temp); nil != err {
+            color.Red("Generate dynamic sql support[%s.%s.%sDynamicSqlSupport] failed, err: %s\n", temp.PackagePath, temp.MapperPackage, temp.TableNameHump, err.Error())
+        } else {
+            generated("Generate dynamic sql support[%s.%s.%sDynamicSqlSupport] success.", temp.PackagePath, temp.MapperPackage, temp.TableNameHump)
+        }
+        return
+    }
+    if "annotation" == mapperMode { // 注解模式下 sql 都在 mapper 接口中
+        return
+    }
+    if 1 == temp.GenerationGap {
+        generateGapMapperXml(temp)
+        return
     }
     if err := generate("mapper", mapperXmlTemp(), mapperXmlPath, "xml", temp); nil != err {
         color.Red("Generate mapper xml[%s%c%s%c%sMapper.xml] failed, err: %s\n", rootPath, filepath.Separator, mapperXmlPath, filepath.Separator, temp.TableNameHump, err.Error())
     } else {
-        color.Green("Generate mapper xml[%s%c%s%c%sMapper.xml] success.", rootPath, filepath.Separator, mapperXmlPath, filepath.Separator, temp.TableNameHump)
+        generated("Generate mapper xml[%s%c%s%c%sMapper.xml] success.", rootPath, filepath.Separator, mapperXmlPath, filepath.Separator, temp.TableNameHump)
+    }
+}
+
+// fillIndexes 填充索引以及根据索引生成的查询方法
+func fillIndexes(temp *TemplateData, indexes []schema.Index) {
+    fields := map[string]column{}
+    for _, v := range temp.Fields {
+        fields[v.Field] = v
+    }
+    finders := map[string]bool{}
+    uniqueFields := map[string]bool{} // 单独构成唯一索引的列
+    for _, v := range indexes {
+        idx := index{Name: v.Name, Finder: "By"}
+        if v.Unique {
+            idx.IsUnique = 1
+        }
+        if v.Primary {
+            idx.IsPrimary = 1
+        }
+        for i, c := range v.Columns {
+            field, ok := fields[c]
+            if !ok { // 表达式索引等无法对应到列
+                idx.Fields = nil
+                break
+            }
+            if 0 < i {
+                idx.Finder += "And"
+            }
+            idx.Finder += field.PropertyN
+            idx.Fields = append(idx.Fields, field)
+        }
+        if 0 == len(idx.Fields) {
+            continue
+        }
+        temp.Indexes = append(temp.Indexes, idx)
+        if v.Unique && 1 == len(idx.Fields) {
+            uniqueFields[idx.Fields[0].Field] = true
+        }
+        if v.Unique && !v.Primary && !finders[idx.Finder] {
+            finders[idx.Finder] = true
+            temp.UniqueIndexes = append(temp.UniqueIndexes, idx)
+        }
+    }
+    for _, v := range temp.Indexes {
+        field := v.Fields[0]
+        if uniqueFields[field.Field] {
+            continue
+        }
+        addListIndex(temp, []column{field})
     }
 }
 
+// addListIndex 添加 listByXxx 查询方法, 已存在时忽略
+func addListIndex(temp *TemplateData, fields []column) {
+    idx := index{Finder: "By", Fields: fields}
+    for i, v := range fields {
+        if 0 < i {
+            idx.Finder += "And"
+        }
+        idx.Finder += v.PropertyN
+    }
+    for _, v := range temp.ListIndexes {
+        if v.Finder == idx.Finder {
+            return
+        }
+    }
+    temp.ListIndexes = append(temp.ListIndexes, idx)
+}
+
+// enumValues 解析 enum('a','b') 或 set('a','b') 中的可选值
+func enumValues(columnType string) []string {
+    lower := strings.ToLower(columnType)
+    if !strings.HasPrefix(lower, "enum(") && !strings.HasPrefix(lower, "set(") {
+        return nil
+    }
+    var values []string
+    inner := columnType[strings.Index(columnType, "(")+1 : strings.LastIndex(columnType, ")")]
+    for i := 0; i < len(inner); i++ {
+        if '\'' != inner[i] {
+            continue
+        }
+        var sb strings.Builder
+        for i++; i < len(inner); i++ {
+            if '\'' == inner[i] {
+                if i+1 < len(inner) && '\'' == inner[i+1] { // '' 表示单引号本身
+                    sb.WriteByte('\'')
+                    i++
+                    continue
+                }
+                break
+            }
+            sb.WriteByte(inner[i])
+        }
+        values = append(values, sb.String())
+    }
+    return values
+}
+
+// 文件已存在时的处理方式
+type overwritePolicy int
+
+const (
+    askOverwrite    overwritePolicy = iota // 询问是否覆盖, 除非指定了 -o
+    alwaysOverwrite                        // 总是覆盖, 用于基类等完全由工具生成的文件
+    createOnly                             // 仅在不存在时创建, 用于用户维护的扩展文件
+)
+
 func generate(title, tempStr, pkg, suffix string, temp *TemplateData) error {
-    var errStr string
-    var fPath string
+    policy := askOverwrite
+    if 1 == temp.GenerationGap { // 基类模式下完全由工具生成的文件总是覆盖
+        policy = alwaysOverwrite
+    }
+    return generateFile(title, tempStr, targetPath(pkg, temp.TableNameHump+toHump(title, true), suffix), policy, temp)
+}
+
+// generateOwned 生成需要用户修改的文件, 基类模式下仅在不存在时创建
+func generateOwned(title, tempStr, pkg, suffix string, temp *TemplateData) error {
+    policy := askOverwrite
+    if 1 == temp.GenerationGap {
+        policy = createOnly
+    }
+    return generateFile(title, tempStr, targetPath(pkg, temp.TableNameHump+toHump(title, true), suffix), policy, temp)
+}
+
+// targetPath 返回生成文件的路径, pkg 为空时生成在根目录下
+func targetPath(pkg, name, suffix string) string {
     if "" == pkg {
-        fPath = fmt.Sprintf("%s%c%s%s.%s", rootPath, filepath.Separator, temp.TableNameHump, toHump(title, true), suffix)
-    } else {
-        fPath = fmt.Sprintf("%s%c%s%c%s%s.%s", rootPath, filepath.Separator,
-            strings.ReplaceAll(pkg, ".", string(filepath.Separator)), filepath.Separator, temp.TableNameHump, toHump(title, true), suffix)
+        return fmt.Sprintf("%s%c%s.%s", rootPath, filepath.Separator, name, suffix)
     }
+    return fmt.Sprintf("%s%c%s%c%s.%s", rootPath, filepath.Separator,
+        strings.ReplaceAll(pkg, ".", string(filepath.Separator)), filepath.Separator, name, suffix)
+}
 
+func generateFile(title, tempStr, fPath string, policy overwritePolicy, temp *TemplateData) (err error) {
+    defer func() {
+        if nil != err {
+            exportFailed = true
+        }
+    }()
+    var errStr string
+    var modified bool // 上次生成后是否被手动修改过
     stat, err := os.Stat(fPath)
     if nil != err {
         if !os.IsNotExist(err) {
-            fmt.Sprintf(errStr, "Failed to generate %s, err: %v", title, err)
+            errStr = fmt.Sprintf("Failed to generate %s, err: %v", title, err)
             return errors.New(errStr)
         }
     } else {
         if stat.IsDir() {
-            fmt.Sprintf(errStr, "The file already exists, but it is a directory[%s]", fPath)
+            errStr = fmt.Sprintf("The file already exists, but it is a directory[%s]", fPath)
             return errors.New(errStr)
         } else {
-            if conflictNoAll {
+            if createOnly == policy {
+                if renderOnly {
+                    recordSkipped(fPath)
+                }
+                keepManifest(fPath)
+                return nil
+            }
+            if modified = !*force && handEdited(fPath); modified && !renderOnly {
+                color.Yellow("The file[%s] has been modified by hand since last generation, skip it, use --force to overwrite\n", fPath)
+                keepManifest(fPath)
                 return nil
             }
-            if conflictOverwriteAll {
+            if alwaysOverwrite == policy || renderOnly {
+                // do nothing
+            } else if conflictNoAll {
+                keepManifest(fPath)
+                return nil
+            } else if conflictOverwriteAll {
                 // do nothing
             } else {
                 isOverwrite := interact.AskIsOverwrite(fPath)
@@ -697,96 +1251,265 @@ func generate(title, tempStr, pkg, suffix string, temp *TemplateData) error {
                     // do nothing
                 } else if "no all" == isOverwrite {
                     conflictNoAll = true
+                    keepManifest(fPath)
                     return nil
                 } else {
                     // do not overwrite
+                    keepManifest(fPath)
                     return nil
                 }
             }
         }
     }
 
-    // 生成它的父目录
-    dir, _ := filepath.Split(fPath)
-    if err = os.MkdirAll(dir, 0750); nil != err {
-        fmt.Sprintf(errStr, "Create %s directory failed, err: %v", title, err)
+    tempEntity, err := template.New(title).Funcs(templateFuncs()).Parse(tempStr) // （2）解析模板
+    if err != nil {
+        errStr = "template parse failed"
         return errors.New(errStr)
     }
-    file, err := os.OpenFile(fPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0750)
-    if nil != err {
-        fmt.Sprintf(errStr, "Open file[%s] failed, err: %v", fPath, err)
+    var buf bytes.Buffer
+    err = tempEntity.Execute(&buf, temp) //（3）数据驱动模板，将name的值填充到模板中
+    if err != nil {
+        errStr = "write to file failed"
         return errors.New(errStr)
     }
+    content := buf.String()
+    var existing []byte
+    if nil != stat { // 覆盖已有文件时保留其中的自定义区域
+        existing, err = os.ReadFile(fPath)
+        if nil != err {
+            errStr = fmt.Sprintf("Read file[%s] failed, err: %v", fPath, err)
+            return errors.New(errStr)
+        }
+        var dropped []string
+        content, dropped = mergeCustomRegions(content, string(existing))
+        for _, name := range dropped {
+            color.Yellow("The custom region[%s] of %s is not found in the template, it will be dropped\n", name, fPath)
+        }
+    }
+    if renderOnly { // 只渲染时记录结果, 不写入文件
+        recordRendered(fPath, nil != stat, modified, string(existing), content)
+        return nil
+    }
 
-    defer file.Close()
-    tempEntity, err := template.New(title).Parse(tempStr) // （2）解析模板
-    if err != nil {
-        errStr = "template parse failed"
+    // 生成它的父目录
+    dir, _ := filepath.Split(fPath)
+    if err = os.MkdirAll(dir, 0750); nil != err {
+        errStr = fmt.Sprintf("Create %s directory failed, err: %v", title, err)
         return errors.New(errStr)
     }
-    err = tempEntity.Execute(file, temp) //（3）数据驱动模板，将name的值填充到模板中
-    if err != nil {
-        errStr = "write to file failed"
+    if err = os.WriteFile(fPath, []byte(content), 0750); nil != err {
+        errStr = fmt.Sprintf("Write file[%s] failed, err: %v", fPath, err)
         return errors.New(errStr)
     }
+    recordManifest(fPath, templateName(tempStr), temp.TableName, content)
     return nil
 }
 
 func entityTemp() string {
+    defaultTemp := config.EntityTemp
+    switch {
+    case "kotlin" == language && "mybatis-plus" == flavor:
+        defaultTemp = config.KotlinPlusEntityTemp
+    case "kotlin" == language:
+        defaultTemp = config.KotlinEntityTemp
+    case "mybatis-plus" == flavor:
+        defaultTemp = config.PlusEntityTemp
+    }
     if "" == entityTemplate {
-        return config.EntityTemp
+        return defaultTemp
     }
     dada, err := os.ReadFile(entityTemplate)
     if nil != err {
         color.Yellow("Read entity template failed, err: %v, Use default.\n", err)
-        return config.EntityTemp
+        return defaultTemp
     }
     return string(dada)
 }
 
 func mapperTemp() string {
+    defaultTemp := config.MapperTemp
+    switch {
+    case "kotlin" == language && "mybatis-plus" == flavor:
+        defaultTemp = config.KotlinPlusMapperTemp
+    case "kotlin" == language:
+        defaultTemp = config.KotlinMapperTemp
+    case "mybatis-plus" == flavor:
+        defaultTemp = config.PlusMapperTemp
+    case "mybatis-dynamic-sql" == flavor:
+        defaultTemp = config.DynamicSqlMapperTemp
+    case "annotation" == mapperMode:
+        defaultTemp = config.AnnotationMapperTemp
+    }
     if "" == mapperTemplate {
-        return config.MapperTemp
+        return defaultTemp
     }
     dada, err := os.ReadFile(mapperTemplate)
     if nil != err {
         color.Yellow("Read mapper template failed, err: %v, Use default.\n", err)
-        return config.MapperTemp
+        return defaultTemp
     }
     return string(dada)
 }
 
 func mapperXmlTemp() string {
+    defaultTemp := config.MapperXmlTemp
+    if "mybatis-plus" == flavor {
+        defaultTemp = config.PlusMapperXmlTemp
+    }
     if "" == mapperXmlTemplate {
-        return config.MapperXmlTemp
+        return defaultTemp
     }
     dada, err := os.ReadFile(mapperXmlTemplate)
     if nil != err {
         color.Yellow("Read mapper xml template failed, err: %v, Use default.\n", err)
-        return config.MapperXmlTemp
+        return defaultTemp
     }
     return string(dada)
 }
 
 func queryTemp() string {
+    defaultTemp := config.QueryTempNew
+    if "kotlin" == language {
+        defaultTemp = config.KotlinQueryTemp
+    }
     if "" == queryTemplate {
-        return config.QueryTempNew
+        return defaultTemp
     }
     dada, err := os.ReadFile(queryTemplate)
     if nil != err {
         color.Yellow("Read query template failed, err: %v, Use default.\n", err)
-        return config.QueryTempNew
+        return defaultTemp
+    }
+    return string(dada)
+}
+
+// templateFuncs 模板中可用的函数
+func templateFuncs() template.FuncMap {
+    return template.FuncMap{
+        "quote":       quote,
+        "jquote":      jquote,
+        "imports":     imports,
+        "paramImport": paramImport,
+        "ktimports":   ktimports,
+        "ktname":      ktname,
+        "indexed":     indexed,
+        "accessor":    accessor,
+        "lcfirst":     lcfirst,
+    }
+}
+
+// quote 按数据库引用标识符, mysql 使用反引号, postgres 和 sqlite 使用双引号
+func quote(name string) string {
+    if "postgres" == driver || "sqlite" == driver {
+        return "\"" + name + "\""
+    }
+    return "`" + name + "`"
+}
+
+// jquote 引用标识符, 并转义为 Java 字符串中的内容
+func jquote(name string) string {
+    return strings.ReplaceAll(quote(name), "\"", "\\\"")
+}
+
+func serviceTemp() string {
+    defaultTemp := config.ServiceTemp
+    if "kotlin" == language {
+        defaultTemp = config.KotlinServiceTemp
+    }
+    if "" == serviceTemplate {
+        return defaultTemp
+    }
+    dada, err := os.ReadFile(serviceTemplate)
+    if nil != err {
+        color.Yellow("Read service template failed, err: %v, Use default.\n", err)
+        return defaultTemp
     }
     return string(dada)
 }
 
+func serviceImplTemp() string {
+    defaultTemp := config.ServiceImplTemp
+    if "kotlin" == language {
+        defaultTemp = config.KotlinServiceImplTemp
+    }
+    if "" == serviceImplTemplate {
+        return defaultTemp
+    }
+    dada, err := os.ReadFile(serviceImplTemplate)
+    if nil != err {
+        color.Yellow("Read service impl template failed, err: %v, Use default.\n", err)
+        return defaultTemp
+    }
+    return string(dada)
+}
+
+func controllerTemp() string {
+    defaultTemp := config.ControllerTemp
+    if "kotlin" == language {
+        defaultTemp = config.KotlinControllerTemp
+    }
+    if "" == controllerTemplate {
+        return defaultTemp
+    }
+    dada, err := os.ReadFile(controllerTemplate)
+    if nil != err {
+        color.Yellow("Read controller template failed, err: %v, Use default.\n", err)
+        return defaultTemp
+    }
+    return string(dada)
+}
+
+func keyTemp() string {
+    defaultTemp := config.KeyTemp
+    if "kotlin" == language {
+        defaultTemp = config.KotlinKeyTemp
+    }
+    if "" == keyTemplate {
+        return defaultTemp
+    }
+    dada, err := os.ReadFile(keyTemplate)
+    if nil != err {
+        color.Yellow("Read key template failed, err: %v, Use default.\n", err)
+        return defaultTemp
+    }
+    return string(dada)
+}
+
+// sourceSuffix 返回生成的源文件后缀
+func sourceSuffix() string {
+    if "kotlin" == language {
+        return "kt"
+    }
+    return "java"
+}
+
+func contains(list []string, s string) bool {
+    for _, v := range list {
+        if v == s {
+            return true
+        }
+    }
+    return false
+}
+
+// tableNameHump 去掉表前缀后转为驼峰, 作为实体类名
+func tableNameHump(tableName string) string {
+    for _, v := range tablePrefixs {
+        if strings.HasPrefix(tableName, v) {
+            return toHump(strings.TrimPrefix(tableName, v), true)
+        }
+    }
+    return toHump(tableName, true)
+}
+
 func toHump(source string, first bool) string {
     if "" == source {
         return ""
     }
     split := strings.Split(source, "_")
     for i, s := range split {
-        if !first && 0 == i {
+        if (!first && 0 == i) || "" == s { // 连续或开头的下划线
             continue
         }
         strArry := []rune(s)
@@ -0,0 +1,67 @@
+package cmd
+
+import (
+    "strings"
+)
+
+type annotation struct {
+    Name   string // 注解名及参数, 不包含 @, 如 Builder, EqualsAndHashCode(callSuper = false)
+    Import string // 注解的全限定类名
+}
+
+// 支持的实体类风格
+var entityStyles = []string{"plain", "lombok", "record"}
+
+// lombok 风格默认使用的注解
+var defaultLombokAnnotations = []string{"Data", "Builder", "NoArgsConstructor", "AllArgsConstructor"}
+
+// parseAnnotations 解析配置的注解, 未指定包名的注解使用 pkg 作为包名, 如 Data 解析为 lombok.Data
+func parseAnnotations(pkg string, list []string) []annotation {
+    var annotations []annotation
+    for _, v := range list {
+        v = strings.TrimPrefix(strings.TrimSpace(v), "@")
+        if "" == v {
+            continue
+        }
+        name, args := v, ""
+        if i := strings.Index(v, "("); -1 != i {
+            name, args = v[:i], v[i:]
+        }
+        fullName := name
+        if !strings.Contains(name, ".") {
+            fullName = pkg + "." + name
+        }
+        annotations = append(annotations, annotation{
+            Name:   fullName[strings.LastIndex(fullName, ".")+1:] + args,
+            Import: fullName,
+        })
+    }
+    return annotations
+}
+
+// accessor 返回实体类读取属性的方法名, record 使用属性名, lombok 的包装类型 Boolean 也使用 get 前缀
+func accessor(style string, c column) string {
+    switch {
+    case "record" == style:
+        return c.Property
+    case "lombok" != style && "Boolean" == c.JavaType:
+        return "is" + c.PropertyN
+    }
+    return "get" + c.PropertyN
+}
+
+// ucfirst 首字母大写
+func ucfirst(name string) string {
+    if "" == name {
+        return name
+    }
+    return strings.ToUpper(name[:1]) + name[1:]
+}
+
+// lcfirst 首字母小写, 用于由类名得到变量名
+func lcfirst(name string) string {
+    if "" == name {
+        return name
+    }
+    return strings.ToLower(name[:1]) + name[1:]
+}
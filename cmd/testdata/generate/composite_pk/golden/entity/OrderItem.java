@@ -0,0 +1,96 @@
+package work.bottle.demo.entity;
+
+import java.io.Serializable;
+import java.math.BigDecimal;
+import java.sql.Timestamp;
+
+public class OrderItem implements Serializable {
+    
+
+    /**
+    * order id
+    */
+    private Long orderId;
+    
+
+    /**
+    * line number
+    */
+    private Integer lineNo;
+    
+
+    /**
+    * sku
+    */
+    private String sku;
+    
+
+    /**
+    * 
+    */
+    private Integer quantity;
+    
+
+    /**
+    * 
+    */
+    private BigDecimal price;
+    
+
+    /**
+    * 
+    */
+    private Timestamp createdAt;
+    
+
+    public void setOrderId(Long orderId) {
+        this.orderId = orderId;
+    }
+    
+    public Long getOrderId() {
+        return this.orderId;
+    }
+
+    public void setLineNo(Integer lineNo) {
+        this.lineNo = lineNo;
+    }
+    
+    public Integer getLineNo() {
+        return this.lineNo;
+    }
+
+    public void setSku(String sku) {
+        this.sku = sku;
+    }
+    
+    public String getSku() {
+        return this.sku;
+    }
+
+    public void setQuantity(Integer quantity) {
+        this.quantity = quantity;
+    }
+    
+    public Integer getQuantity() {
+        return this.quantity;
+    }
+
+    public void setPrice(BigDecimal price) {
+        this.price = price;
+    }
+    
+    public BigDecimal getPrice() {
+        return this.price;
+    }
+
+    public void setCreatedAt(Timestamp createdAt) {
+        this.createdAt = createdAt;
+    }
+    
+    public Timestamp getCreatedAt() {
+        return this.createdAt;
+    }
+
+    // @custom-begin methods
+    // @custom-end
+}
@@ -0,0 +1,9 @@
+CREATE TABLE `bt_order_item` (
+  `order_id` bigint unsigned NOT NULL COMMENT 'order id',
+  `line_no` int NOT NULL COMMENT 'line number',
+  `sku` varchar(32) NOT NULL COMMENT 'sku',
+  `quantity` int NOT NULL DEFAULT 1,
+  `price` decimal(10,2) DEFAULT NULL,
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`order_id`, `line_no`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='order item';
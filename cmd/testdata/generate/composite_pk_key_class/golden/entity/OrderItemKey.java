@@ -0,0 +1,35 @@
+package work.bottle.demo.entity;
+
+import java.io.Serializable;
+
+public class OrderItemKey implements Serializable {
+    
+
+    /**
+    * order id
+    */
+    private Long orderId;
+    
+
+    /**
+    * line number
+    */
+    private Integer lineNo;
+    
+
+    public void setOrderId(Long orderId) {
+        this.orderId = orderId;
+    }
+
+    public Long getOrderId() {
+        return this.orderId;
+    }
+
+    public void setLineNo(Integer lineNo) {
+        this.lineNo = lineNo;
+    }
+
+    public Integer getLineNo() {
+        return this.lineNo;
+    }
+}
@@ -0,0 +1,30 @@
+package work.bottle.demo.mapper;
+
+import org.apache.ibatis.annotations.Mapper;
+import org.apache.ibatis.annotations.Param;
+import work.bottle.demo.entity.OrderItem;
+import work.bottle.demo.entity.OrderItemKey;
+import work.bottle.demo.entity.query.OrderItemQuery;
+
+import java.util.List;
+
+@Mapper
+public interface OrderItemMapper {
+
+    int count(OrderItemQuery query);
+
+    List<OrderItem> list(OrderItemQuery query);
+
+    List<OrderItem> listByOrderId(@Param("orderId") Long orderId);
+
+    int insert(OrderItem entity);
+
+    OrderItem getByPk(OrderItemKey key);
+
+    int update(OrderItem entity);
+
+	int delete(OrderItemKey key);
+
+    // @custom-begin methods
+    // @custom-end
+}
@@ -0,0 +1,49 @@
+package work.bottle.demo.entity;
+
+import java.io.Serializable;
+import java.util.List;
+
+public class Author implements Serializable {
+    
+
+    /**
+    * 
+    */
+    private Integer id;
+    
+
+    /**
+    * 
+    */
+    private String name;
+    
+
+    private List<Book> bookList;
+
+    public void setId(Integer id) {
+        this.id = id;
+    }
+    
+    public Integer getId() {
+        return this.id;
+    }
+
+    public void setName(String name) {
+        this.name = name;
+    }
+    
+    public String getName() {
+        return this.name;
+    }
+
+    public void setBookList(List<Book> bookList) {
+        this.bookList = bookList;
+    }
+
+    public List<Book> getBookList() {
+        return this.bookList;
+    }
+
+    // @custom-begin methods
+    // @custom-end
+}
@@ -0,0 +1,62 @@
+package work.bottle.demo.entity;
+
+import java.io.Serializable;
+
+public class Book implements Serializable {
+    
+
+    /**
+    * 
+    */
+    private Integer id;
+    
+
+    /**
+    * 
+    */
+    private Integer authorId;
+    
+
+    /**
+    * 
+    */
+    private String title;
+    
+
+    private Author author;
+
+    public void setId(Integer id) {
+        this.id = id;
+    }
+    
+    public Integer getId() {
+        return this.id;
+    }
+
+    public void setAuthorId(Integer authorId) {
+        this.authorId = authorId;
+    }
+    
+    public Integer getAuthorId() {
+        return this.authorId;
+    }
+
+    public void setTitle(String title) {
+        this.title = title;
+    }
+    
+    public String getTitle() {
+        return this.title;
+    }
+
+    public void setAuthor(Author author) {
+        this.author = author;
+    }
+
+    public Author getAuthor() {
+        return this.author;
+    }
+
+    // @custom-begin methods
+    // @custom-end
+}
@@ -0,0 +1,161 @@
+package work.bottle.demo.entity.query;
+
+import java.io.Serializable;
+import java.util.HashMap;
+import java.util.HashSet;
+import java.util.Map;
+import java.util.Set;
+
+public class BookQuery implements Serializable {
+	private String sortBy;
+    private String sortOrder;
+    private Integer page;
+    private Integer pageCnt;
+
+	private Map<String, String> allowSortBy;
+    private Set<String> queryFields;
+    /**
+    * 
+    */
+    private Integer id;
+    /**
+    * 
+    */
+    private Integer authorId;
+    
+	public BookQuery() {
+        this.page = 1;
+        this.pageCnt = 20;
+        this.allowSortBy = initAllowSortBy();
+        this.queryFields = initQueryFields();
+    }
+
+    protected Map<String, String> initAllowSortBy() {
+        HashMap<String, String> allowSortByMap = new HashMap<>();
+        allowSortByMap.put("id", "id");
+        return allowSortByMap;
+    }
+
+    protected Set<String> initQueryFields() {
+        HashSet<String> fieldSet = new HashSet<>();
+        
+        fieldSet.add("id");
+        fieldSet.add("author_id");
+        fieldSet.add("title");
+        
+        return fieldSet;
+    }
+
+
+    public String getSortBy() {
+        return sortBy;
+    }
+
+    public void setSortBy(String sortBy) {
+        if (null == allowSortBy) {
+            return;
+        }
+        if (!allowSortBy.containsKey(sortBy)) {
+            return;
+        }
+        this.sortBy = allowSortBy.get(sortBy);
+    }
+
+    public String getSortOrder() {
+        return sortOrder;
+    }
+
+    public void setSortOrder(String sortOrder) {
+		if (!"ASC".equals(sortOrder) && !"DESC".equals(sortOrder)) {
+			this.sortOrder = "DESC";
+		} else {
+			this.sortOrder = sortOrder;
+		}
+    }
+
+    public Integer getPage() {
+        if (null != this.page && this.page > 0) {
+            return this.page;
+        }
+        return 1;
+    }
+
+    public void setPage(Integer page) {
+        this.page = page;
+    }
+
+    public void nextPage() {
+        this.page++;
+    }
+
+    public void prevPage() {
+        this.page--;
+    }
+
+    public Integer getPageCnt() {
+        if (null == this.pageCnt || this.pageCnt <= 0) {
+            return 20;
+        }
+        return this.pageCnt;
+    }
+
+    public void setPageCnt(Integer pageCnt) {
+        this.pageCnt = pageCnt;
+    }
+
+    public Integer getOffset() {
+        if (null != this.page && this.page > 0) {
+            if (null == this.pageCnt || this.pageCnt <= 0) {
+                return (this.page - 1) * 20;
+            }
+            return (this.page - 1) * this.pageCnt;
+        } else {
+            return 0;
+        }
+    }
+
+    public Integer getLength() {
+        if (null == this.pageCnt || this.pageCnt <= 0) {
+            return 20;
+        }
+        return this.pageCnt;
+    }
+
+    public void setAllowSortBy(Map<String, String> allowSortBy) {
+        this.allowSortBy = allowSortBy;
+    }
+
+    public Map<String, String> getAllowSortBy() {
+        return allowSortBy;
+    }
+
+    public void setQueryFields(Set<String> queryFields) {
+        this.queryFields = queryFields;
+    }
+
+    public Set<String> getQueryFields() {
+        return queryFields;
+    }
+
+    public Set<String> addQueryField(String field) {
+        queryFields.add(field);
+        return queryFields;
+    }
+
+    public Set<String> removeQueryField(String field) {
+        queryFields.remove(field);
+        return queryFields;
+    }
+    public void setId(Integer id) {
+        this.id = id;
+    }
+    public Integer getId() {
+        return this.id;
+    }
+    public void setAuthorId(Integer authorId) {
+        this.authorId = authorId;
+    }
+    public Integer getAuthorId() {
+        return this.authorId;
+    }
+}
\ No newline at end of file
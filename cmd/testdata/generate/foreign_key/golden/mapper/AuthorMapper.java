@@ -0,0 +1,29 @@
+package work.bottle.demo.mapper;
+
+import org.apache.ibatis.annotations.Mapper;
+import org.apache.ibatis.annotations.Param;
+import work.bottle.demo.entity.Author;
+import work.bottle.demo.entity.query.AuthorQuery;
+
+import java.util.List;
+
+@Mapper
+public interface AuthorMapper {
+
+    int count(AuthorQuery query);
+
+    List<Author> list(AuthorQuery query);
+
+    int insert(Author entity);
+
+    Author getByPk(@Param("id") Integer id);
+
+    Author getByPkWithRelations(@Param("id") Integer id);
+
+    int update(Author entity);
+
+	int delete(@Param("id") Integer id);
+
+    // @custom-begin methods
+    // @custom-end
+}
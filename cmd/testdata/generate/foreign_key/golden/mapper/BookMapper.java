@@ -0,0 +1,31 @@
+package work.bottle.demo.mapper;
+
+import org.apache.ibatis.annotations.Mapper;
+import org.apache.ibatis.annotations.Param;
+import work.bottle.demo.entity.Book;
+import work.bottle.demo.entity.query.BookQuery;
+
+import java.util.List;
+
+@Mapper
+public interface BookMapper {
+
+    int count(BookQuery query);
+
+    List<Book> list(BookQuery query);
+
+    List<Book> listByAuthorId(@Param("authorId") Integer authorId);
+
+    int insert(Book entity);
+
+    Book getByPk(@Param("id") Integer id);
+
+    Book getByPkWithRelations(@Param("id") Integer id);
+
+    int update(Book entity);
+
+	int delete(@Param("id") Integer id);
+
+    // @custom-begin methods
+    // @custom-end
+}
@@ -0,0 +1,12 @@
+CREATE TABLE `bt_author` (
+  `id` int NOT NULL AUTO_INCREMENT,
+  `name` varchar(64) NOT NULL,
+  PRIMARY KEY (`id`)
+) ENGINE=InnoDB COMMENT='author';
+CREATE TABLE `bt_book` (
+  `id` int NOT NULL AUTO_INCREMENT,
+  `author_id` int NOT NULL,
+  `title` varchar(128) NOT NULL,
+  PRIMARY KEY (`id`),
+  CONSTRAINT `fk_book_author` FOREIGN KEY (`author_id`) REFERENCES `bt_author` (`id`)
+) ENGINE=InnoDB COMMENT='book';
@@ -0,0 +1,95 @@
+package work.bottle.demo.entity;
+
+import java.io.Serializable;
+import java.sql.Timestamp;
+
+public class Account implements Serializable {
+    
+
+    /**
+    * id
+    */
+    private Long id;
+    
+
+    /**
+    * tenant
+    */
+    private Integer tenantId;
+    
+
+    /**
+    * email
+    */
+    private String email;
+    
+
+    /**
+    * 
+    */
+    private String code;
+    
+
+    /**
+    * 
+    */
+    private Integer status;
+    
+
+    /**
+    * 
+    */
+    private Timestamp createdAt;
+    
+
+    public void setId(Long id) {
+        this.id = id;
+    }
+    
+    public Long getId() {
+        return this.id;
+    }
+
+    public void setTenantId(Integer tenantId) {
+        this.tenantId = tenantId;
+    }
+    
+    public Integer getTenantId() {
+        return this.tenantId;
+    }
+
+    public void setEmail(String email) {
+        this.email = email;
+    }
+    
+    public String getEmail() {
+        return this.email;
+    }
+
+    public void setCode(String code) {
+        this.code = code;
+    }
+    
+    public String getCode() {
+        return this.code;
+    }
+
+    public void setStatus(Integer status) {
+        this.status = status;
+    }
+    
+    public Integer getStatus() {
+        return this.status;
+    }
+
+    public void setCreatedAt(Timestamp createdAt) {
+        this.createdAt = createdAt;
+    }
+    
+    public Timestamp getCreatedAt() {
+        return this.createdAt;
+    }
+
+    // @custom-begin methods
+    // @custom-end
+}
@@ -0,0 +1,35 @@
+package work.bottle.demo.mapper;
+
+import org.apache.ibatis.annotations.Mapper;
+import org.apache.ibatis.annotations.Param;
+import work.bottle.demo.entity.Account;
+import work.bottle.demo.entity.query.AccountQuery;
+
+import java.util.List;
+
+@Mapper
+public interface AccountMapper {
+
+    int count(AccountQuery query);
+
+    List<Account> list(AccountQuery query);
+
+    Account getByEmail(@Param("email") String email);
+
+    Account getByTenantIdAndCode(@Param("tenantId") Integer tenantId, @Param("code") String code);
+
+    List<Account> listByTenantId(@Param("tenantId") Integer tenantId);
+
+    List<Account> listByStatus(@Param("status") Integer status);
+
+    int insert(Account entity);
+
+    Account getByPk(@Param("id") Long id);
+
+    int update(Account entity);
+
+	int delete(@Param("id") Long id);
+
+    // @custom-begin methods
+    // @custom-end
+}
@@ -0,0 +1,13 @@
+CREATE TABLE `bt_account` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT COMMENT 'id',
+  `tenant_id` int NOT NULL COMMENT 'tenant',
+  `email` varchar(128) NOT NULL COMMENT 'email',
+  `code` varchar(32) NOT NULL,
+  `status` tinyint NOT NULL DEFAULT 0,
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `uk_email` (`email`),
+  UNIQUE KEY `uk_tenant_code` (`tenant_id`, `code`),
+  KEY `idx_status` (`status`),
+  KEY `idx_tenant_created` (`tenant_id`, `created_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='account';
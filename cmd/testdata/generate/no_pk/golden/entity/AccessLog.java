@@ -0,0 +1,67 @@
+package work.bottle.demo.entity;
+
+import java.io.Serializable;
+import java.sql.Timestamp;
+
+public class AccessLog implements Serializable {
+    
+
+    /**
+    * 
+    */
+    private String path;
+    
+
+    /**
+    * 
+    */
+    private Integer status;
+    
+
+    /**
+    * 
+    */
+    private String remoteAddr;
+    
+
+    /**
+    * 
+    */
+    private Timestamp at;
+    
+
+    public void setPath(String path) {
+        this.path = path;
+    }
+    
+    public String getPath() {
+        return this.path;
+    }
+
+    public void setStatus(Integer status) {
+        this.status = status;
+    }
+    
+    public Integer getStatus() {
+        return this.status;
+    }
+
+    public void setRemoteAddr(String remoteAddr) {
+        this.remoteAddr = remoteAddr;
+    }
+    
+    public String getRemoteAddr() {
+        return this.remoteAddr;
+    }
+
+    public void setAt(Timestamp at) {
+        this.at = at;
+    }
+    
+    public Timestamp getAt() {
+        return this.at;
+    }
+
+    // @custom-begin methods
+    // @custom-end
+}
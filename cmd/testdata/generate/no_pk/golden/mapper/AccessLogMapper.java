@@ -0,0 +1,20 @@
+package work.bottle.demo.mapper;
+
+import org.apache.ibatis.annotations.Mapper;
+import work.bottle.demo.entity.AccessLog;
+import work.bottle.demo.entity.query.AccessLogQuery;
+
+import java.util.List;
+
+@Mapper
+public interface AccessLogMapper {
+
+    int count(AccessLogQuery query);
+
+    List<AccessLog> list(AccessLogQuery query);
+
+    int insert(AccessLog entity);
+
+    // @custom-begin methods
+    // @custom-end
+}
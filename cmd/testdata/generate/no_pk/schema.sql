@@ -0,0 +1,6 @@
+CREATE TABLE `bt_access_log` (
+  `path` varchar(255) NOT NULL,
+  `status` smallint NOT NULL,
+  `remote_addr` varchar(64) DEFAULT NULL,
+  `at` datetime(3) NOT NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='access log';
@@ -0,0 +1,160 @@
+package cmd
+
+import (
+    "regexp"
+    "strings"
+
+    "github.com/fatih/color"
+)
+
+// TypeMapping 数据库类型到 Java 类型的映射, 配置文件中的映射优先于内置映射
+type TypeMapping struct {
+    SqlType  string   `yaml:"sql-type"`  // 数据库类型, 不区分大小写, 如 varchar; 带参数时匹配完整类型, 如 tinyint(1)
+    Unsigned *bool    `yaml:"unsigned"`  // 提供时只匹配无符号(true)或有符号(false)的列
+    Column   string   `yaml:"column"`    // 列名正则, 提供时只匹配列名符合的列
+    JavaType string   `yaml:"java-type"` // Java 类型, 可以是全限定类名, 生成时转为简单类名并自动导入
+    JdbcType string   `yaml:"jdbc-type"` // JDBC 类型
+    Imports  []string `yaml:"imports"`   // Java 类型需要的 import
+
+    columnRegexp *regexp.Regexp
+    postgres     bool // 只用于 PostgreSQL, MySQL 中的同名类型保持原来的映射
+}
+
+// 配置文件中的类型映射
+var typeMappings []TypeMapping
+
+// 内置的类型映射
+var defaultTypeMappings = []TypeMapping{
+    {SqlType: "int", JdbcType: "INTEGER", JavaType: "Integer"},
+    {SqlType: "integer", JdbcType: "INTEGER", JavaType: "Integer"},
+    {SqlType: "mediumint", JdbcType: "INTEGER", JavaType: "Integer"},
+    {SqlType: "varchar", JdbcType: "VARCHAR", JavaType: "String"},
+    {SqlType: "tinyint", JdbcType: "TINYINT", JavaType: "Integer"},
+    {SqlType: "smallint", JdbcType: "SMALLINT", JavaType: "Integer"},
+    {SqlType: "real", JdbcType: "REAL", JavaType: "Object"},
+    {SqlType: "numeric", JdbcType: "NUMERIC", JavaType: "java.math.BigDecimal"},
+    {SqlType: "float", JdbcType: "FLOAT", JavaType: "Float"},
+    {SqlType: "double", JdbcType: "DOUBLE", JavaType: "Double"},
+    {SqlType: "decimal", JdbcType: "DECIMAL", JavaType: "java.math.BigDecimal"},
+    {SqlType: "clob", JdbcType: "CLOB", JavaType: "String"},
+    {SqlType: "text", JdbcType: "CLOB", JavaType: "String"},
+    {SqlType: "char", JdbcType: "CHAR", JavaType: "String"},
+    {SqlType: "blob", JdbcType: "BLOB", JavaType: "Byte[]"},
+    {SqlType: "bit", JdbcType: "BIT", JavaType: "Byte"},
+    {SqlType: "bigint", JdbcType: "BIGINT", JavaType: "Long"},
+    // PostgreSQL
+    {SqlType: "int2", JdbcType: "SMALLINT", JavaType: "Integer"},
+    {SqlType: "smallserial", JdbcType: "SMALLINT", JavaType: "Integer"},
+    {SqlType: "int4", JdbcType: "INTEGER", JavaType: "Integer"},
+    {SqlType: "serial", JdbcType: "INTEGER", JavaType: "Integer"},
+    {SqlType: "int8", JdbcType: "BIGINT", JavaType: "Long"},
+    {SqlType: "bigserial", JdbcType: "BIGINT", JavaType: "Long"},
+    {SqlType: "float4", JdbcType: "REAL", JavaType: "Float"},
+    {SqlType: "float8", JdbcType: "DOUBLE", JavaType: "Double"},
+    {SqlType: "bool", JdbcType: "BOOLEAN", JavaType: "Boolean"},
+    {SqlType: "bpchar", JdbcType: "CHAR", JavaType: "String"},
+    {SqlType: "uuid", JdbcType: "OTHER", JavaType: "java.util.UUID"},
+    {SqlType: "json", JdbcType: "OTHER", JavaType: "String", postgres: true},
+    {SqlType: "jsonb", JdbcType: "OTHER", JavaType: "String", postgres: true},
+    {SqlType: "bytea", JdbcType: "BINARY", JavaType: "byte[]"},
+}
+
+// 日期时间类型的映射策略
+var dateTimeMappings = map[string][]TypeMapping{
+    "legacy": {
+        {SqlType: "timestamp", JdbcType: "TIMESTAMP", JavaType: "java.sql.Timestamp"},
+        {SqlType: "datetime", JdbcType: "TIMESTAMP", JavaType: "java.sql.Timestamp"},
+        {SqlType: "timestamptz", JdbcType: "TIMESTAMP", JavaType: "java.sql.Timestamp"},
+        {SqlType: "date", JdbcType: "DATE", JavaType: "java.sql.Date"},
+        {SqlType: "time", JdbcType: "TIME", JavaType: "java.sql.Time"},
+        {SqlType: "timetz", JdbcType: "TIME", JavaType: "java.sql.Time"},
+    },
+    "java-time": {
+        {SqlType: "timestamp", JdbcType: "TIMESTAMP", JavaType: "java.time.LocalDateTime"},
+        {SqlType: "datetime", JdbcType: "TIMESTAMP", JavaType: "java.time.LocalDateTime"},
+        {SqlType: "timestamptz", JdbcType: "TIMESTAMP_WITH_TIMEZONE", JavaType: "java.time.OffsetDateTime"},
+        {SqlType: "date", JdbcType: "DATE", JavaType: "java.time.LocalDate"},
+        {SqlType: "time", JdbcType: "TIME", JavaType: "java.time.LocalTime"},
+        {SqlType: "timetz", JdbcType: "TIME_WITH_TIMEZONE", JavaType: "java.time.OffsetTime"},
+    },
+    "java-time-offset": {
+        {SqlType: "timestamp", JdbcType: "TIMESTAMP_WITH_TIMEZONE", JavaType: "java.time.OffsetDateTime"},
+        {SqlType: "datetime", JdbcType: "TIMESTAMP_WITH_TIMEZONE", JavaType: "java.time.OffsetDateTime"},
+        {SqlType: "timestamptz", JdbcType: "TIMESTAMP_WITH_TIMEZONE", JavaType: "java.time.OffsetDateTime"},
+        {SqlType: "date", JdbcType: "DATE", JavaType: "java.time.LocalDate"},
+        {SqlType: "time", JdbcType: "TIME", JavaType: "java.time.LocalTime"},
+        {SqlType: "timetz", JdbcType: "TIME_WITH_TIMEZONE", JavaType: "java.time.OffsetTime"},
+    },
+}
+
+// loadTypeMappings 校验配置文件中的类型映射, 忽略无效的条目
+func loadTypeMappings(mappings []TypeMapping) []TypeMapping {
+    var list []TypeMapping
+    for _, v := range mappings {
+        if "" == v.SqlType || "" == v.JavaType {
+            color.Yellow("Type mapping %v is ignored, sql-type and java-type are required\n", v.SqlType)
+            continue
+        }
+        if "" != v.Column {
+            re, err := regexp.Compile(v.Column)
+            if nil != err {
+                color.Yellow("Type mapping %v is ignored, column %v is not a valid regexp: %v\n", v.SqlType, v.Column, err)
+                continue
+            }
+            v.columnRegexp = re
+        }
+        list = append(list, v)
+    }
+    return list
+}
+
+// match 判断映射是否适用于该列
+func (m *TypeMapping) match(field, dataType, columnType string) bool {
+    sqlType := strings.ToLower(m.SqlType)
+    if strings.Contains(sqlType, "(") { // 带参数时匹配完整类型, 忽略 unsigned 等修饰
+        columnType = strings.ToLower(columnType)
+        if !strings.HasPrefix(columnType, sqlType) || (len(columnType) > len(sqlType) && ' ' != columnType[len(sqlType)]) {
+            return false
+        }
+    } else if sqlType != strings.ToLower(dataType) {
+        return false
+    }
+    if nil != m.Unsigned && *m.Unsigned != strings.Contains(strings.ToLower(columnType), "unsigned") {
+        return false
+    }
+    if nil != m.columnRegexp && !m.columnRegexp.MatchString(field) {
+        return false
+    }
+    return true
+}
+
+// columnType 返回数据库类型对应的 jdbcType, javaType 以及 javaType 需要的 import, javaType 中的全限定类名转为简单类名
+func columnType(field, dataType, colType string) (jdbcType string, javaType string, imports []string) {
+    jdbcType, javaType, imports = lookupType(field, dataType, colType)
+    javaType, qualified := simpleType(javaType)
+    return jdbcType, javaType, append(qualified, imports...)
+}
+
+// lookupType 依次查找配置文件中的映射、日期时间映射和内置映射
+func lookupType(field, dataType, colType string) (jdbcType string, javaType string, imports []string) {
+    strategy := dateTime
+    if "" == strategy {
+        strategy = "legacy"
+    }
+    for _, list := range [][]TypeMapping{typeMappings, dateTimeMappings[strategy], defaultTypeMappings} {
+        for i := range list {
+            if list[i].postgres && "postgres" != driver {
+                continue
+            }
+            if list[i].match(field, dataType, colType) {
+                return list[i].JdbcType, list[i].JavaType, list[i].Imports
+            }
+        }
+    }
+    if strings.HasPrefix(dataType, "_") { // PostgreSQL 数组, 如 _int4
+        elemType := strings.TrimPrefix(dataType, "_")
+        _, javaType, imports = lookupType(field, elemType, strings.TrimSuffix(colType, "[]"))
+        return "ARRAY", javaType + "[]", imports
+    }
+    return "", "Object", nil
+}
@@ -0,0 +1,173 @@
+package cmd
+
+import (
+    "testing"
+)
+
+// withTypeMappings 在测试期间使用指定的日期时间策略和配置文件中的类型映射
+func withTypeMappings(t *testing.T, strategy string, mappings []TypeMapping) {
+    oldDateTime, oldMappings := dateTime, typeMappings
+    dateTime, typeMappings = strategy, mappings
+    t.Cleanup(func() {
+        dateTime, typeMappings = oldDateTime, oldMappings
+    })
+}
+
+// TestLookupTypeBaseline 内置映射与原先 switch 中的映射一致, 包括大写的类型名
+func TestLookupTypeBaseline(t *testing.T) {
+    withTypeMappings(t, "", nil)
+    baseline := []struct {
+        dataTypes []string
+        jdbcType  string
+        javaType  string
+    }{
+        {[]string{"int", "integer", "INT", "INTEGER"}, "INTEGER", "Integer"},
+        {[]string{"mediumint", "MEDIUMINT"}, "INTEGER", "Integer"},
+        {[]string{"varchar", "VARCHAR"}, "VARCHAR", "String"},
+        {[]string{"tinyint", "TINYINT"}, "TINYINT", "Integer"},
+        {[]string{"timestamp", "datetime", "TIMESTAMP", "DATETIME"}, "TIMESTAMP", "java.sql.Timestamp"},
+        {[]string{"time", "TIME"}, "TIME", "java.sql.Time"},
+        {[]string{"smallint", "SMALLINT"}, "SMALLINT", "Integer"},
+        {[]string{"real", "REAL"}, "REAL", "Object"},
+        {[]string{"numeric", "NUMERIC"}, "NUMERIC", "BigDecimal"},
+        {[]string{"float", "FLOAT"}, "FLOAT", "Float"},
+        {[]string{"double", "DOUBLE"}, "DOUBLE", "Double"},
+        {[]string{"decimal", "DECIMAL"}, "DECIMAL", "BigDecimal"},
+        {[]string{"date", "DATE"}, "DATE", "java.sql.Date"},
+        {[]string{"clob", "CLOB", "text", "TEXT"}, "CLOB", "String"},
+        {[]string{"char", "CHAR"}, "CHAR", "String"},
+        {[]string{"blob", "BLOB"}, "BLOB", "Byte[]"},
+        {[]string{"bit", "BIT"}, "BIT", "Byte"},
+        {[]string{"bigint", "BIGINT"}, "BIGINT", "Long"},
+        {[]string{"json", "geometry", "enum"}, "", "Object"},
+    }
+    for _, tt := range baseline {
+        for _, dataType := range tt.dataTypes {
+            jdbcType, javaType, _ := columnType("c", dataType, dataType)
+            want, _ := simpleType(tt.javaType)
+            if jdbcType != tt.jdbcType || javaType != want {
+                t.Errorf("%s: got %s/%s, want %s/%s", dataType, jdbcType, javaType, tt.jdbcType, want)
+            }
+        }
+    }
+}
+
+func TestLookupType(t *testing.T) {
+    unsigned, signed := true, false
+    mappings := []TypeMapping{
+        {SqlType: "tinyint(1)", JavaType: "Boolean", JdbcType: "BOOLEAN"},
+        {SqlType: "bigint", Unsigned: &unsigned, JavaType: "java.math.BigInteger", JdbcType: "BIGINT"},
+        {SqlType: "int", Unsigned: &signed, Column: "^(id|.*_id)$", JavaType: "Long", JdbcType: "INTEGER"},
+        {SqlType: "json", Column: "_ext$", JavaType: "com.fasterxml.jackson.databind.JsonNode", JdbcType: "OTHER"},
+        {SqlType: "varchar", Column: "(", JavaType: "Object"}, // 无效的正则, 忽略
+        {SqlType: "text", JdbcType: "CLOB"},                   // 缺少 java-type, 忽略
+    }
+    loaded := loadTypeMappings(mappings)
+    if 4 != len(loaded) {
+        t.Fatalf("loaded %d type mappings, want 4", len(loaded))
+    }
+    tests := []struct {
+        name       string
+        strategy   string
+        field      string
+        dataType   string
+        columnType string
+        jdbcType   string
+        javaType   string
+    }{
+        {"tinyint(1)", "", "active", "tinyint", "tinyint(1)", "BOOLEAN", "Boolean"},
+        {"tinyint(1) unsigned", "", "active", "tinyint", "tinyint(1) unsigned", "BOOLEAN", "Boolean"},
+        {"tinyint(10) is not tinyint(1)", "", "level", "tinyint", "tinyint(10)", "TINYINT", "Integer"},
+        {"tinyint", "", "level", "tinyint", "tinyint", "TINYINT", "Integer"},
+        {"bigint unsigned", "", "id", "bigint", "bigint(20) unsigned", "BIGINT", "java.math.BigInteger"},
+        {"bigint signed", "", "id", "bigint", "bigint(20)", "BIGINT", "Long"},
+        {"int signed id", "", "user_id", "int", "int(11)", "INTEGER", "Long"},
+        {"int unsigned id", "", "user_id", "int", "int(11) unsigned", "INTEGER", "Integer"},
+        {"int not id", "", "amount", "int", "int(11)", "INTEGER", "Integer"},
+        {"json column regexp", "", "attr_ext", "json", "json", "OTHER", "com.fasterxml.jackson.databind.JsonNode"},
+        {"json other column", "", "payload", "json", "json", "", "Object"},
+        {"invalid regexp ignored", "", "name", "varchar", "varchar(10)", "VARCHAR", "String"},
+        {"missing java type ignored", "", "note", "text", "text", "CLOB", "String"},
+        {"postgres array", "", "ids", "_int4", "integer[]", "ARRAY", "Integer[]"},
+        {"postgres array of mapped type", "", "flags", "_bool", "boolean[]", "ARRAY", "Boolean[]"},
+        {"postgres array of date time", "java-time", "times", "_timestamptz", "timestamp with time zone[]", "ARRAY", "java.time.OffsetDateTime[]"},
+        {"postgres array of unknown type", "", "points", "_point", "point[]", "ARRAY", "Object[]"},
+        {"unknown type", "", "shape", "geometry", "geometry", "", "Object"},
+        {"legacy timestamp", "legacy", "at", "timestamp", "timestamp", "TIMESTAMP", "java.sql.Timestamp"},
+        {"legacy datetime", "legacy", "at", "datetime", "datetime(3)", "TIMESTAMP", "java.sql.Timestamp"},
+        {"legacy timestamptz", "legacy", "at", "timestamptz", "timestamp with time zone", "TIMESTAMP", "java.sql.Timestamp"},
+        {"legacy date", "legacy", "at", "date", "date", "DATE", "java.sql.Date"},
+        {"legacy time", "legacy", "at", "time", "time", "TIME", "java.sql.Time"},
+        {"legacy timetz", "legacy", "at", "timetz", "time with time zone", "TIME", "java.sql.Time"},
+        {"java-time timestamp", "java-time", "at", "timestamp", "timestamp", "TIMESTAMP", "java.time.LocalDateTime"},
+        {"java-time datetime", "java-time", "at", "datetime", "datetime", "TIMESTAMP", "java.time.LocalDateTime"},
+        {"java-time timestamptz", "java-time", "at", "timestamptz", "timestamp with time zone", "TIMESTAMP_WITH_TIMEZONE", "java.time.OffsetDateTime"},
+        {"java-time date", "java-time", "at", "date", "date", "DATE", "java.time.LocalDate"},
+        {"java-time time", "java-time", "at", "time", "time", "TIME", "java.time.LocalTime"},
+        {"java-time timetz", "java-time", "at", "timetz", "time with time zone", "TIME_WITH_TIMEZONE", "java.time.OffsetTime"},
+        {"java-time-offset timestamp", "java-time-offset", "at", "timestamp", "timestamp", "TIMESTAMP_WITH_TIMEZONE", "java.time.OffsetDateTime"},
+        {"java-time-offset datetime", "java-time-offset", "at", "datetime", "datetime", "TIMESTAMP_WITH_TIMEZONE", "java.time.OffsetDateTime"},
+        {"java-time-offset timestamptz", "java-time-offset", "at", "timestamptz", "timestamp with time zone", "TIMESTAMP_WITH_TIMEZONE", "java.time.OffsetDateTime"},
+        {"java-time-offset date", "java-time-offset", "at", "date", "date", "DATE", "java.time.LocalDate"},
+        {"java-time-offset time", "java-time-offset", "at", "time", "time", "TIME", "java.time.LocalTime"},
+        {"java-time-offset timetz", "java-time-offset", "at", "timetz", "time with time zone", "TIME_WITH_TIMEZONE", "java.time.OffsetTime"},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            withTypeMappings(t, tt.strategy, loaded)
+            jdbcType, javaType, _ := lookupType(tt.field, tt.dataType, tt.columnType)
+            if jdbcType != tt.jdbcType || javaType != tt.javaType {
+                t.Errorf("got %s/%s, want %s/%s", jdbcType, javaType, tt.jdbcType, tt.javaType)
+            }
+        })
+    }
+}
+
+func TestLookupTypePostgres(t *testing.T) {
+    withTypeMappings(t, "", nil)
+    oldDriver := driver
+    driver = "postgres"
+    defer func() {
+        driver = oldDriver
+    }()
+    tests := []struct {
+        dataType string
+        jdbcType string
+        javaType string
+    }{
+        {"json", "OTHER", "String"},
+        {"jsonb", "OTHER", "String"},
+        {"int8", "BIGINT", "Long"},
+        {"bool", "BOOLEAN", "Boolean"},
+        {"uuid", "OTHER", "java.util.UUID"},
+        {"_uuid", "ARRAY", "java.util.UUID[]"},
+    }
+    for _, tt := range tests {
+        jdbcType, javaType, _ := lookupType("c", tt.dataType, tt.dataType)
+        if jdbcType != tt.jdbcType || javaType != tt.javaType {
+            t.Errorf("%s: got %s/%s, want %s/%s", tt.dataType, jdbcType, javaType, tt.jdbcType, tt.javaType)
+        }
+    }
+}
+
+func TestColumnTypeImports(t *testing.T) {
+    withTypeMappings(t, "java-time", loadTypeMappings([]TypeMapping{
+        {SqlType: "json", JavaType: "JsonNode", JdbcType: "OTHER", Imports: []string{"com.fasterxml.jackson.databind.JsonNode"}},
+    }))
+    tests := []struct {
+        dataType string
+        javaType string
+        imports  []string
+    }{
+        {"decimal", "BigDecimal", []string{"java.math.BigDecimal"}},
+        {"datetime", "LocalDateTime", []string{"java.time.LocalDateTime"}},
+        {"json", "JsonNode", []string{"com.fasterxml.jackson.databind.JsonNode"}},
+        {"varchar", "String", nil},
+    }
+    for _, tt := range tests {
+        _, javaType, imports := columnType("c", tt.dataType, tt.dataType)
+        if javaType != tt.javaType || len(imports) != len(tt.imports) || (0 < len(imports) && imports[0] != tt.imports[0]) {
+            t.Errorf("%s: got %s %v, want %s %v", tt.dataType, javaType, imports, tt.javaType, tt.imports)
+        }
+    }
+}
@@ -208,11 +208,7 @@ public class {{ .TableNameHump }}Query extends Query {
 `
     QueryTempNew = `package {{ .PackagePath }}.{{ .QueryPackage }};
 
-import java.io.Serializable;
-import java.util.HashMap;
-import java.util.HashSet;
-import java.util.Map;
-import java.util.Set;
+{{ imports (print .PackagePath "." .QueryPackage) "java.io.Serializable" "java.util.HashMap" "java.util.HashSet" "java.util.Map" "java.util.Set" (indexed .Fields) }}
 
 public class {{ .TableNameHump }}Query implements Serializable {
 	private String sortBy;
@@ -242,7 +238,9 @@ public class {{ .TableNameHump }}Query implements Serializable {
 
     protected Map<String, String> initAllowSortBy() {
         HashMap<String, String> allowSortByMap = new HashMap<>();
-        allowSortByMap.put("{{ .Pk }}", "{{ .Pk }}");
+        {{- range $v := .Pks }}
+        allowSortByMap.put("{{ $v.Field }}", "{{ $v.Field }}");
+        {{- end }}
         return allowSortByMap;
     }
 
@@ -376,9 +374,29 @@ public class {{ .TableNameHump }}Query implements Serializable {
 
     EntityTemp = `package {{ .PackagePath }}.{{ .EntityPackage }};
 
-import java.io.Serializable;
-
-public class {{ .TableNameHump }} implements Serializable {
+{{ imports (print .PackagePath "." .EntityPackage) "java.io.Serializable" .Fields (and .Collections "java.util.List") .EntityAnnotations }}
+{{ if eq .EntityStyle "record" }}
+/**
+ * {{ .TableNote }}
+ *
+{{- range $v := .Fields }}
+ * @param {{ $v.Property }} {{ $v.Comment }}
+{{- end }}
+ */
+public record {{ .TableNameHump }}(
+{{- range $i, $v := .Fields }}{{ if $i }},{{ end }}
+        {{ $v.JavaType }} {{ $v.Property }}
+{{- end }}
+) implements Serializable {
+
+    // @custom-begin methods
+    // @custom-end
+}
+{{- else }}
+{{- range $a := .EntityAnnotations }}
+@{{ $a.Name }}
+{{- end }}
+public class {{ .TableNameHump }}{{ if eq .GenerationGap 1 }}BaseEntity{{ end }} implements Serializable {
     {{ range $v := .Fields }}
 
     /**
@@ -386,6 +404,15 @@ public class {{ .TableNameHump }} implements Serializable {
     */
     private {{ $v.JavaType }} {{ $v.Property }};
     {{ end }}
+    {{- range $r := .Associations }}
+
+    private {{ $r.TableNameHump }} {{ $r.Property }};
+    {{- end }}
+    {{- range $r := .Collections }}
+
+    private List<{{ $r.TableNameHump }}> {{ $r.Property }};
+    {{- end }}
+    {{- if ne .EntityStyle "lombok" }}
 
     {{- range $v := .Fields }}
 
@@ -402,29 +429,98 @@ public class {{ .TableNameHump }} implements Serializable {
     }
     {{- end -}}
     {{- end }}
+    {{- range $r := .Associations }}
+
+    public void set{{ $r.PropertyN }}({{ $r.TableNameHump }} {{ $r.Property }}) {
+        this.{{ $r.Property }} = {{ $r.Property }};
+    }
+
+    public {{ $r.TableNameHump }} get{{ $r.PropertyN }}() {
+        return this.{{ $r.Property }};
+    }
+    {{- end }}
+    {{- range $r := .Collections }}
+
+    public void set{{ $r.PropertyN }}(List<{{ $r.TableNameHump }}> {{ $r.Property }}) {
+        this.{{ $r.Property }} = {{ $r.Property }};
+    }
+
+    public List<{{ $r.TableNameHump }}> get{{ $r.PropertyN }}() {
+        return this.{{ $r.Property }};
+    }
+    {{- end }}
+    {{- end }}
+
+    // @custom-begin methods
+    // @custom-end
 }
+{{- end }}
 `
-    MapperTemp = `package {{ .PackagePath }}.{{ .MapperPackage }};
+    KeyTemp = `package {{ .PackagePath }}.{{ .EntityPackage }};
+
+{{ imports (print .PackagePath "." .EntityPackage) "java.io.Serializable" .Pks }}
+
+public class {{ .TableNameHump }}Key implements Serializable {
+    {{ range $v := .Pks }}
+
+    /**
+    * {{ $v.Comment }}
+    */
+    private {{ $v.JavaType }} {{ $v.Property }};
+    {{ end }}
+
+    {{- range $v := .Pks }}
+
+    public void set{{- $v.PropertyN }}({{$v.JavaType}} {{$v.Property}}) {
+        this.{{$v.Property}} = {{$v.Property}};
+    }
 
-import {{ .PackagePath }}.{{ .EntityPackage }}.{{ .TableNameHump }};
-import {{ .PackagePath }}.{{ .QueryPackage }}.{{ .TableNameHump }}Query;
-import org.apache.ibatis.annotations.Mapper;
-import org.apache.ibatis.annotations.Param;
+    public {{$v.JavaType}} get{{- $v.PropertyN}}() {
+        return this.{{$v.Property}};
+    }
+    {{- end }}
+}
+`
+    MapperTemp = `package {{ .PackagePath }}.{{ .MapperPackage }};
 
-import java.util.List;
+{{ imports (print .PackagePath "." .MapperPackage) (print .PackagePath "." .EntityPackage "." .TableNameHump) (and (eq .KeyClass 1) (print .PackagePath "." .EntityPackage "." .TableNameHump "Key")) (print .PackagePath "." .QueryPackage "." .TableNameHump "Query") (and (ne .GenerationGap 1) "org.apache.ibatis.annotations.Mapper") (paramImport .) "java.util.List" .Pks .UniqueIndexes .ListIndexes }}
 
+{{ if eq .GenerationGap 1 -}}
+public interface {{ .TableNameHump }}BaseMapper {
+{{- else -}}
 @Mapper
 public interface {{ .TableNameHump }}Mapper {
+{{- end }}
 
     int count({{ .TableNameHump }}Query query);
 
     List<{{ .TableNameHump }}> list({{ .TableNameHump }}Query query);
+    {{- range $idx := .UniqueIndexes }}
+
+    {{ $.TableNameHump }} get{{ $idx.Finder }}({{ range $i, $v := $idx.Fields }}{{ if $i }}, {{ end }}@Param("{{ $v.Property }}") {{ $v.JavaType }} {{ $v.Property }}{{ end }});
+    {{- end }}
+    {{- range $idx := .ListIndexes }}
+
+    List<{{ $.TableNameHump }}> list{{ $idx.Finder }}({{ range $i, $v := $idx.Fields }}{{ if $i }}, {{ end }}@Param("{{ $v.Property }}") {{ $v.JavaType }} {{ $v.Property }}{{ end }});
+    {{- end }}
 
     int insert({{ .TableNameHump }} entity);
 
+    {{- if .Pks }}
+
+    {{ .TableNameHump }} getByPk({{ if eq .KeyClass 1 }}{{ .TableNameHump }}Key key{{ else }}{{ range $i, $v := .Pks }}{{ if $i }}, {{ end }}@Param("{{ $v.Property }}") {{ $v.JavaType }} {{ $v.Property }}{{ end }}{{ end }});
+    {{- if or .Associations .Collections }}
+
+    {{ .TableNameHump }} getByPkWithRelations({{ if eq .KeyClass 1 }}{{ .TableNameHump }}Key key{{ else }}{{ range $i, $v := .Pks }}{{ if $i }}, {{ end }}@Param("{{ $v.Property }}") {{ $v.JavaType }} {{ $v.Property }}{{ end }}{{ end }});
+    {{- end }}
+
     int update({{ .TableNameHump }} entity);
 
-	int delete(@Param("{{ .Pk }}") {{ .PkType }} {{ .Pk }});
+	int delete({{ if eq .KeyClass 1 }}{{ .TableNameHump }}Key key{{ else }}{{ range $i, $v := .Pks }}{{ if $i }}, {{ end }}@Param("{{ $v.Property }}") {{ $v.JavaType }} {{ $v.Property }}{{ end }}{{ end }});
+    {{- end }}
+
+    // @custom-begin methods
+    // @custom-end
 }
 `
     MapperXmlTemp = `<!-- {{ .TableNote }} -->
@@ -434,6 +530,13 @@ public interface {{ .TableNameHump }}Mapper {
 
 <mapper namespace="{{.PackagePath}}.{{ .MapperPackage }}.{{ .TableNameHump }}Mapper">
     <resultMap id="{{- .TableNameHump -}}" type="{{- .PackagePath -}}.{{- .EntityPackage -}}.{{- .TableNameHump -}}">
+    {{- if eq .EntityStyle "record" }}
+        <constructor>
+        {{- range $v := .Fields }}
+            <{{ if eq $v.IsPk 1 }}idArg{{ else }}arg{{ end }} column="{{ $v.Field }}" javaType="{{ $v.FullJavaType }}" jdbcType="{{ $v.JdbcType }}" />
+        {{- end }}
+        </constructor>
+    {{- else }}
 	{{- range $v := .Fields -}}
 	{{- if eq $v.IsPk 1 }}
 		<id column="{{- $v.Field -}}" property="{{- $v.Property -}}" jdbcType="{{- $v.JdbcType -}}" />
@@ -441,13 +544,24 @@ public interface {{ .TableNameHump }}Mapper {
 		<result column="{{ $v.Field }}" property="{{ $v.Property }}" jdbcType="{{ $v.JdbcType }}" />
 	{{- end -}}
 	{{- end }}
+    {{- end }}
+    </resultMap>
+    {{- if or .Associations .Collections }}
+    <resultMap id="{{ .TableNameHump }}WithRelations" type="{{ .PackagePath }}.{{ .EntityPackage }}.{{ .TableNameHump }}" extends="{{ .TableNameHump }}">
+        {{- range $r := .Associations }}
+        <association property="{{ $r.Property }}" javaType="{{ $.PackagePath }}.{{ $.EntityPackage }}.{{ $r.TableNameHump }}" column="{{ $r.Column }}" select="{{ $.PackagePath }}.{{ $.MapperPackage }}.{{ $r.TableNameHump }}Mapper.{{ $r.Select }}" fetchType="{{ if eq $.Language "kotlin" }}eager{{ else }}lazy{{ end }}" />
+        {{- end }}
+        {{- range $r := .Collections }}
+        <collection property="{{ $r.Property }}" ofType="{{ $.PackagePath }}.{{ $.EntityPackage }}.{{ $r.TableNameHump }}" column="{{ $r.Column }}" select="{{ $.PackagePath }}.{{ $.MapperPackage }}.{{ $r.TableNameHump }}Mapper.{{ $r.Select }}" fetchType="{{ if eq $.Language "kotlin" }}eager{{ else }}lazy{{ end }}" />
+        {{- end }}
     </resultMap>
+    {{- end }}
     <select id="list" resultMap="{{.TableNameHump}}">
         select
         <choose>
             <when test="null != queryFields">
                 <foreach collection="queryFields" separator="," item="Field">
-                    ` + "`${Field}`" + `
+                    {{ quote "${Field}" }}
                 </foreach>
             </when>
             <otherwise>
@@ -459,18 +573,19 @@ public interface {{ .TableNameHump }}Mapper {
 			{{- range $v := .Fields -}}
 			{{ if or (eq $v.IsIndex 1) (eq $v.IsPk 1) }}
             <if test="{{$v.Property}} != null">
-                and ` + "`{{ $v.Field }}`" + ` = #{ {{ $v.Property }}, jdbcType={{ $v.JdbcType }} }
+                and {{ quote $v.Field }} = #{ {{ $v.Property }}, jdbcType={{ $v.JdbcType }} }
             </if>
 			{{- end }}
 			{{- end }}
         </where>
+        {{- if .Pks }}
         order by
         <choose>
             <when test="sortBy != null">
                 ${sortBy}
             </when>
             <otherwise>
-                {{ .Pk }}
+                {{ range $i, $v := .Pks }}{{ if $i }}, {{ end }}{{ quote $v.Field }}{{ end }}
             </otherwise>
         </choose>
         <choose>
@@ -481,22 +596,30 @@ public interface {{ .TableNameHump }}Mapper {
                 asc
             </otherwise>
         </choose>
+        {{- else }}
+        <if test="sortBy != null">
+            order by ${sortBy}
+            <if test="sortOrder != null">
+                ${sortOrder}
+            </if>
+        </if>
+        {{- end }}
         limit
         <choose>
-            <when test="offset != null and offset >= 0">
-                #{offset}
+            <when test="length != null and length > 0">
+                #{length}
             </when>
             <otherwise>
-                0
+                20
             </otherwise>
         </choose>
-        ,
+        offset
         <choose>
-            <when test="length != null and length > 0">
-                #{length}
+            <when test="offset != null and offset >= 0">
+                #{offset}
             </when>
             <otherwise>
-                20
+                0
             </otherwise>
         </choose>
     </select>
@@ -506,40 +629,64 @@ public interface {{ .TableNameHump }}Mapper {
 			{{- range $v := .Fields -}}
 			{{ if or (eq $v.IsIndex 1) (eq $v.IsPk 1) }}
             <if test="{{$v.Property}} != null">
-                and ` + "`{{ $v.Field }}`" + ` = #{ {{ $v.Property }}, jdbcType={{ $v.JdbcType }} }
+                and {{ quote $v.Field }} = #{ {{ $v.Property }}, jdbcType={{ $v.JdbcType }} }
             </if>
 			{{- end }}
 			{{- end }}
         </where>
         limit 1
     </select>
+    {{- range $idx := .UniqueIndexes }}
+    <select id="get{{ $idx.Finder }}" resultMap="{{ $.TableNameHump }}">
+        select * from {{ $.TableName }}
+        where {{ range $i, $v := $idx.Fields }}{{ if $i }} and {{ end }}{{ quote $v.Field }} = #{ {{ $v.Property }}{{ if $v.JdbcType }}, jdbcType={{ $v.JdbcType }}{{ end }} }{{ end }}
+    </select>
+    {{- end }}
+    {{- range $idx := .ListIndexes }}
+    <select id="list{{ $idx.Finder }}" resultMap="{{ $.TableNameHump }}">
+        select * from {{ $.TableName }}
+        where {{ range $i, $v := $idx.Fields }}{{ if $i }} and {{ end }}{{ quote $v.Field }} = #{ {{ $v.Property }}{{ if $v.JdbcType }}, jdbcType={{ $v.JdbcType }}{{ end }} }{{ end }}
+    </select>
+    {{- end }}
+    {{- if .Pks }}
+    <select id="getByPk" resultMap="{{ .TableNameHump }}">
+        select * from {{ .TableName }}
+        where {{ range $i, $v := .Pks }}{{ if $i }} and {{ end }}{{ quote $v.Field }} = #{ {{ $v.Property }}{{ if $v.JdbcType }}, jdbcType={{ $v.JdbcType }}{{ end }} }{{ end }}
+    </select>
+    {{- if or .Associations .Collections }}
+    <select id="getByPkWithRelations" resultMap="{{ .TableNameHump }}WithRelations">
+        select * from {{ .TableName }}
+        where {{ range $i, $v := .Pks }}{{ if $i }} and {{ end }}{{ quote $v.Field }} = #{ {{ $v.Property }}{{ if $v.JdbcType }}, jdbcType={{ $v.JdbcType }}{{ end }} }{{ end }}
+    </select>
+    {{- end }}
     <update id="update" parameterType="{{.PackagePath}}.{{- .EntityPackage -}}.{{.TableNameHump}}">
         update {{ .TableName }}
         <set>
             {{- range $v := .Fields -}}
-            {{ if ne $v.IsPk 1 }}
+            {{ if and (ne $v.IsPk 1) (ne $v.IsGenerated 1) }}
             <if test="{{ $v.Property }} != null">
-                ` + "`{{ $v.Field }}`" + ` = #{ {{ $v.Property }},jdbcType={{ $v.JdbcType }} },
+                {{ quote $v.Field }} = #{ {{ $v.Property }},jdbcType={{ $v.JdbcType }} },
             </if>
 			{{- end }}
 			{{- end }}
         </set>
-        where {{ .Pk }} = #{ {{ .PkHump }} }
+        where {{ range $i, $v := .Pks }}{{ if $i }} and {{ end }}{{ quote $v.Field }} = #{ {{ $v.Property }}{{ if $v.JdbcType }}, jdbcType={{ $v.JdbcType }}{{ end }} }{{ end }}
     </update>
-    <insert id="insert" parameterType="{{ .PackagePath }}.{{ .EntityPackage }}.{{ .TableNameHump }}" keyProperty="{{ .Pk }}" useGeneratedKeys="true">
+    {{- end }}
+    <insert id="insert" parameterType="{{ .PackagePath }}.{{ .EntityPackage }}.{{ .TableNameHump }}"{{ if eq .UseGeneratedKeys 1 }} keyProperty="{{ (index .Pks 0).Property }}" useGeneratedKeys="true"{{ end }}>
         insert into {{ .TableName }}
         <trim prefix="(" suffix=")" suffixOverrides=",">
             {{- range $v := .Fields -}}
-            {{ if eq 1 1 }}
+            {{ if ne $v.IsGenerated 1 }}
             <if test="{{ $v.Property }} != null">
-                ` + "`{{ $v.Field }}`" + `,
+                {{ quote $v.Field }},
             </if>
             {{- end }}
             {{- end }}
         </trim>
         <trim prefix="values(" suffix=")" suffixOverrides=",">
             {{- range $v := .Fields -}}
-            {{ if eq 1 1 }}
+            {{ if ne $v.IsGenerated 1 }}
             <if test="{{ $v.Property }} != null">
                 #{ {{ $v.Property }},jdbcType={{ $v.JdbcType }} },
             </if>
@@ -547,12 +694,21 @@ public interface {{ .TableNameHump }}Mapper {
             {{- end }}
         </trim>
     </insert>
+    {{- if .Pks }}
     <delete id="delete">
-        delete from {{ .TableName }} where {{ .Pk }} = #{ {{ .PkHump }} }
+        delete from {{ .TableName }}
+        where {{ range $i, $v := .Pks }}{{ if $i }} and {{ end }}{{ quote $v.Field }} = #{ {{ $v.Property }}{{ if $v.JdbcType }}, jdbcType={{ $v.JdbcType }}{{ end }} }{{ end }}
     </delete>
+    {{- end }}
+
+    <!-- @custom-begin statements -->
+    <!-- @custom-end -->
 </mapper>
 `
-    ConfigTemp = `host: localhost
+    ConfigTemp = `driver: mysql
+#ddl: schema.sql
+#schema-file: schema.yaml
+host: localhost
 port: 3306
 user: root
 password: 123123
@@ -569,9 +725,55 @@ entity-package: entity
 mapper-package: mapper
 query-package: entity.query
 mapper-xml-path: resource
+#service-package: service
+#service-impl-package: service.impl
+#controller-package: controller
+#dto-package: dto
+#mapstruct: true
+#dto-exclude:
+#    create:
+#        - created_at
+#    update:
+#        - created_at
+#    vo:
+#        - password
+#        - bt_user.salt
 entity-template: template/entity.ftl
 mapper-template: template/mapper.ftl
 query-template: template/query.ftl
 mapper-xml-template: template/mapperXml.ftl
+#key-class: true
+#generation-gap: true # 生成总是覆盖的 XxxBaseEntity, XxxBaseMapper, BaseXxxMapper.xml, 以及仅创建一次的 Xxx, XxxMapper, XxxMapper.xml
+#key-template: template/key.ftl
+#service-template: template/service.ftl
+#service-impl-template: template/serviceImpl.ftl
+#controller-template: template/controller.ftl
+#dto-template: template/dto.ftl
+#date-time: java-time
+#language: kotlin
+#flavor: mybatis-plus # mybatis, mybatis-plus 或 mybatis-dynamic-sql
+#mapper-mode: annotation # xml 或 annotation, annotation 时不生成 mapper xml
+#logic-delete-column: deleted
+#version-column: version
+#entity-style: lombok # plain, lombok 或 record
+#lombok-annotations:
+#    - Data
+#    - Builder
+#    - NoArgsConstructor
+#    - AllArgsConstructor
+#type-mapping:
+#    - sql-type: tinyint(1)
+#      java-type: Boolean
+#      jdbc-type: BOOLEAN
+#    - sql-type: bigint
+#      unsigned: true
+#      java-type: java.math.BigInteger
+#      jdbc-type: BIGINT
+#    - sql-type: json
+#      column: _ext$
+#      java-type: JsonNode
+#      jdbc-type: OTHER
+#      imports:
+#          - com.fasterxml.jackson.databind.JsonNode
 `
 )
@@ -0,0 +1,167 @@
+package config
+
+const (
+    AnnotationMapperTemp = `{{- define "param" }}#{ {{ .Property }}{{ if .JdbcType }}, jdbcType={{ .JdbcType }}{{ end }} }{{ end -}}
+{{- define "where" }}
+        "<where>",
+        {{- range $v := .Fields }}
+        {{- if or (eq $v.IsIndex 1) (eq $v.IsPk 1) }}
+        "<if test='{{ $v.Property }} != null'> and {{ jquote $v.Field }} = {{ template "param" $v }}</if>",
+        {{- end }}
+        {{- end }}
+        "</where>",
+{{- end -}}
+{{- define "pkParams" }}{{ if eq .KeyClass 1 }}{{ .TableNameHump }}Key key{{ else }}{{ range $i, $v := .Pks }}{{ if $i }}, {{ end }}@Param("{{ $v.Property }}") {{ $v.JavaType }} {{ $v.Property }}{{ end }}{{ end }}{{ end -}}
+{{- define "pkWhere" }}where {{ range $i, $v := .Pks }}{{ if $i }} and {{ end }}{{ jquote $v.Field }} = {{ template "param" $v }}{{ end }}{{ end -}}
+package {{ .PackagePath }}.{{ .MapperPackage }};
+
+{{ imports (print .PackagePath "." .MapperPackage) (print .PackagePath "." .EntityPackage "." .TableNameHump) (and (eq .KeyClass 1) (print .PackagePath "." .EntityPackage "." .TableNameHump "Key")) (print .PackagePath "." .QueryPackage "." .TableNameHump "Query") (and (ne .GenerationGap 1) "org.apache.ibatis.annotations.Mapper") (paramImport .) "org.apache.ibatis.annotations.Select" "org.apache.ibatis.annotations.Insert" "org.apache.ibatis.annotations.ResultMap" "org.apache.ibatis.type.JdbcType" "java.util.List" (and .Pks "org.apache.ibatis.annotations.Update") (and .Pks "org.apache.ibatis.annotations.Delete") (and (eq .UseGeneratedKeys 1) "org.apache.ibatis.annotations.Options") (and (eq .EntityStyle "record") "org.apache.ibatis.annotations.ConstructorArgs") (and (eq .EntityStyle "record") "org.apache.ibatis.annotations.Arg") "org.apache.ibatis.annotations.Results" (and (ne .EntityStyle "record") "org.apache.ibatis.annotations.Result") (and .Associations "org.apache.ibatis.annotations.One") (and .Collections "org.apache.ibatis.annotations.Many") (and (or .Associations .Collections) "org.apache.ibatis.mapping.FetchType") .Pks .UniqueIndexes .ListIndexes }}
+
+{{ if eq .GenerationGap 1 -}}
+public interface {{ .TableNameHump }}BaseMapper {
+{{- else -}}
+@Mapper
+public interface {{ .TableNameHump }}Mapper {
+{{- end }}
+
+    @Select({
+        "<script>",
+        "select count(*) as cnt from {{ .TableName }}",
+        {{- template "where" . }}
+        "</script>",
+    })
+    int count({{ .TableNameHump }}Query query);
+
+    @Select({
+        "<script>",
+        "select",
+        "<choose>",
+        "<when test='null != queryFields'>",
+        "<foreach collection='queryFields' separator=',' item='Field'>{{ jquote "${Field}" }}</foreach>",
+        "</when>",
+        "<otherwise>*</otherwise>",
+        "</choose>",
+        "from {{ .TableName }}",
+        {{- template "where" . }}
+        {{- if .Pks }}
+        "order by",
+        "<choose>",
+        "<when test='sortBy != null'>${sortBy}</when>",
+        "<otherwise>{{ range $i, $v := .Pks }}{{ if $i }}, {{ end }}{{ jquote $v.Field }}{{ end }}</otherwise>",
+        "</choose>",
+        "<choose>",
+        "<when test='sortOrder != null'>${sortOrder}</when>",
+        "<otherwise>asc</otherwise>",
+        "</choose>",
+        {{- else }}
+        "<if test='sortBy != null'>order by ${sortBy} <if test='sortOrder != null'>${sortOrder}</if></if>",
+        {{- end }}
+        "limit",
+        "<choose>",
+        "<when test='length != null and length > 0'>#{length}</when>",
+        "<otherwise>20</otherwise>",
+        "</choose>",
+        "offset",
+        "<choose>",
+        "<when test='offset != null and offset >= 0'>#{offset}</when>",
+        "<otherwise>0</otherwise>",
+        "</choose>",
+        "</script>",
+    })
+    {{- if eq .EntityStyle "record" }}
+    @Results(id = "{{ .TableNameHump }}")
+    @ConstructorArgs({
+        {{- range $v := .Fields }}
+        @Arg(column = "{{ $v.Field }}", javaType = {{ $v.JavaType }}.class{{ if $v.JdbcType }}, jdbcType = JdbcType.{{ $v.JdbcType }}{{ end }}{{ if eq $v.IsPk 1 }}, id = true{{ end }}),
+        {{- end }}
+    })
+    {{- else }}
+    @Results(id = "{{ .TableNameHump }}", value = {
+        {{- range $v := .Fields }}
+        @Result(column = "{{ $v.Field }}", property = "{{ $v.Property }}"{{ if $v.JdbcType }}, jdbcType = JdbcType.{{ $v.JdbcType }}{{ end }}{{ if eq $v.IsPk 1 }}, id = true{{ end }}),
+        {{- end }}
+    })
+    {{- end }}
+    List<{{ .TableNameHump }}> list({{ .TableNameHump }}Query query);
+    {{- range $idx := .UniqueIndexes }}
+
+    @Select("select * from {{ $.TableName }} where {{ range $i, $v := $idx.Fields }}{{ if $i }} and {{ end }}{{ jquote $v.Field }} = {{ template "param" $v }}{{ end }}")
+    @ResultMap("{{ $.TableNameHump }}")
+    {{ $.TableNameHump }} get{{ $idx.Finder }}({{ range $i, $v := $idx.Fields }}{{ if $i }}, {{ end }}@Param("{{ $v.Property }}") {{ $v.JavaType }} {{ $v.Property }}{{ end }});
+    {{- end }}
+    {{- range $idx := .ListIndexes }}
+
+    @Select("select * from {{ $.TableName }} where {{ range $i, $v := $idx.Fields }}{{ if $i }} and {{ end }}{{ jquote $v.Field }} = {{ template "param" $v }}{{ end }}")
+    @ResultMap("{{ $.TableNameHump }}")
+    List<{{ $.TableNameHump }}> list{{ $idx.Finder }}({{ range $i, $v := $idx.Fields }}{{ if $i }}, {{ end }}@Param("{{ $v.Property }}") {{ $v.JavaType }} {{ $v.Property }}{{ end }});
+    {{- end }}
+
+    @Insert({
+        "<script>",
+        "insert into {{ .TableName }}",
+        "<trim prefix='(' suffix=')' suffixOverrides=','>",
+        {{- range $v := .Fields }}
+        {{- if ne $v.IsGenerated 1 }}
+        "<if test='{{ $v.Property }} != null'>{{ jquote $v.Field }},</if>",
+        {{- end }}
+        {{- end }}
+        "</trim>",
+        "<trim prefix='values(' suffix=')' suffixOverrides=','>",
+        {{- range $v := .Fields }}
+        {{- if ne $v.IsGenerated 1 }}
+        "<if test='{{ $v.Property }} != null'>{{ template "param" $v }},</if>",
+        {{- end }}
+        {{- end }}
+        "</trim>",
+        "</script>",
+    })
+    {{- if eq .UseGeneratedKeys 1 }}
+    @Options(useGeneratedKeys = true, keyProperty = "{{ (index .Pks 0).Property }}")
+    {{- end }}
+    int insert({{ .TableNameHump }} entity);
+    {{- if .Pks }}
+
+    @Select("select * from {{ .TableName }} {{ template "pkWhere" . }}")
+    @ResultMap("{{ .TableNameHump }}")
+    {{ .TableNameHump }} getByPk({{ template "pkParams" . }});
+    {{- if or .Associations .Collections }}
+
+    @Select("select * from {{ .TableName }} {{ template "pkWhere" . }}")
+    @Results(id = "{{ .TableNameHump }}WithRelations", value = {
+        {{- range $v := .Fields }}
+        @Result(column = "{{ $v.Field }}", property = "{{ $v.Property }}"{{ if $v.JdbcType }}, jdbcType = JdbcType.{{ $v.JdbcType }}{{ end }}{{ if eq $v.IsPk 1 }}, id = true{{ end }}),
+        {{- end }}
+        {{- range $r := .Associations }}
+        @Result(column = "{{ $r.Column }}", property = "{{ $r.Property }}", one = @One(select = "{{ $.PackagePath }}.{{ $.MapperPackage }}.{{ $r.TableNameHump }}Mapper.{{ $r.Select }}", fetchType = FetchType.LAZY)),
+        {{- end }}
+        {{- range $r := .Collections }}
+        @Result(column = "{{ $r.Column }}", property = "{{ $r.Property }}", many = @Many(select = "{{ $.PackagePath }}.{{ $.MapperPackage }}.{{ $r.TableNameHump }}Mapper.{{ $r.Select }}", fetchType = FetchType.LAZY)),
+        {{- end }}
+    })
+    {{ .TableNameHump }} getByPkWithRelations({{ template "pkParams" . }});
+    {{- end }}
+
+    @Update({
+        "<script>",
+        "update {{ .TableName }}",
+        "<set>",
+        {{- range $v := .Fields }}
+        {{- if and (ne $v.IsPk 1) (ne $v.IsGenerated 1) }}
+        "<if test='{{ $v.Property }} != null'>{{ jquote $v.Field }} = {{ template "param" $v }},</if>",
+        {{- end }}
+        {{- end }}
+        "</set>",
+        "{{ template "pkWhere" . }}",
+        "</script>",
+    })
+    int update({{ .TableNameHump }} entity);
+
+    @Delete("delete from {{ .TableName }} {{ template "pkWhere" . }}")
+    int delete({{ template "pkParams" . }});
+    {{- end }}
+
+    // @custom-begin methods
+    // @custom-end
+}
+`
+)
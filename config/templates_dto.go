@@ -0,0 +1,99 @@
+package config
+
+const (
+    DtoTemp = `package {{ .PackagePath }}.{{ .DtoPackage }};
+
+{{ imports (print .PackagePath "." .DtoPackage) "java.io.Serializable" .DtoFields .EntityAnnotations }}
+{{ if eq .EntityStyle "record" }}
+/**
+ * {{ .TableNote }}
+ *
+{{- range $v := .DtoFields }}
+ * @param {{ $v.Property }} {{ $v.Comment }}
+{{- end }}
+ */
+public record {{ .TableNameHump }}{{ .DtoClass }}(
+{{- range $i, $v := .DtoFields }}{{ if $i }},{{ end }}
+        {{ $v.JavaType }} {{ $v.Property }}
+{{- end }}
+) implements Serializable {
+}
+{{- else }}
+/**
+ * {{ .TableNote }}
+ */
+{{- range $a := .EntityAnnotations }}
+@{{ $a.Name }}
+{{- end }}
+public class {{ .TableNameHump }}{{ .DtoClass }} implements Serializable {
+    {{- range $v := .DtoFields }}
+
+    /**
+     * {{ $v.Comment }}
+     */
+    private {{ $v.JavaType }} {{ $v.Property }};
+    {{- end }}
+    {{- if ne .EntityStyle "lombok" }}
+    {{- range $v := .DtoFields }}
+
+    public void set{{ $v.PropertyN }}({{ $v.JavaType }} {{ $v.Property }}) {
+        this.{{ $v.Property }} = {{ $v.Property }};
+    }
+
+    public {{ $v.JavaType }} {{ accessor $.EntityStyle $v }}() {
+        return this.{{ $v.Property }};
+    }
+    {{- end }}
+    {{- end }}
+}
+{{- end }}
+`
+    ConverterTemp = `package {{ .PackagePath }}.{{ .DtoPackage }};
+
+{{ imports (print .PackagePath "." .DtoPackage) (print .PackagePath "." .EntityPackage "." .TableNameHump) "org.mapstruct.Mapper" "org.mapstruct.ReportingPolicy" "java.util.List" }}
+
+@Mapper(componentModel = "spring", unmappedTargetPolicy = ReportingPolicy.IGNORE)
+public interface {{ .TableNameHump }}Converter {
+
+    {{ .TableNameHump }} fromCreateRequest({{ .TableNameHump }}CreateRequest request);
+
+    {{ .TableNameHump }} fromUpdateRequest({{ .TableNameHump }}UpdateRequest request);
+
+    {{ .TableNameHump }}VO toVO({{ .TableNameHump }} entity);
+
+    List<{{ .TableNameHump }}VO> toVOList(List<{{ .TableNameHump }}> entities);
+}
+`
+    KotlinDtoTemp = `package {{ .PackagePath }}.{{ .DtoPackage }}
+
+{{ ktimports (print .PackagePath "." .DtoPackage) "java.io.Serializable" .DtoFields }}
+
+/**
+ * {{ .TableNote }}
+ */
+data class {{ .TableNameHump }}{{ .DtoClass }}(
+{{- range $v := .DtoFields }}
+    /**
+     * {{ $v.Comment }}
+     */
+    var {{ ktname $v.Property }}: {{ $v.KotlinType }}{{ if eq $v.KotlinNullable 1 }}?{{ end }} = {{ $v.KotlinDefault }},
+{{- end }}
+) : Serializable
+`
+    KotlinConverterTemp = `package {{ .PackagePath }}.{{ .DtoPackage }}
+
+{{ ktimports (print .PackagePath "." .DtoPackage) (print .PackagePath "." .EntityPackage "." .TableNameHump) "org.mapstruct.Mapper" "org.mapstruct.ReportingPolicy" }}
+
+@Mapper(componentModel = "spring", unmappedTargetPolicy = ReportingPolicy.IGNORE)
+interface {{ .TableNameHump }}Converter {
+
+    fun fromCreateRequest(request: {{ .TableNameHump }}CreateRequest): {{ .TableNameHump }}
+
+    fun fromUpdateRequest(request: {{ .TableNameHump }}UpdateRequest): {{ .TableNameHump }}
+
+    fun toVO(entity: {{ .TableNameHump }}): {{ .TableNameHump }}VO
+
+    fun toVOList(entities: List<{{ .TableNameHump }}>): List<{{ .TableNameHump }}VO>
+}
+`
+)
@@ -0,0 +1,168 @@
+package config
+
+const (
+    DynamicSqlSupportTemp = `package {{ .PackagePath }}.{{ .MapperPackage }};
+
+{{ imports (print .PackagePath "." .MapperPackage) "java.sql.JDBCType" "org.mybatis.dynamic.sql.SqlColumn" "org.mybatis.dynamic.sql.SqlTable" .Fields }}
+
+/**
+ * {{ .TableNote }}
+ */
+public final class {{ .TableNameHump }}DynamicSqlSupport {
+    public static final {{ .TableNameHump }} {{ lcfirst .TableNameHump }} = new {{ .TableNameHump }}();
+    {{- range $v := .Fields }}
+
+    /**
+     * {{ $v.Comment }}
+     */
+    public static final SqlColumn<{{ $v.JavaType }}> {{ $v.Property }} = {{ lcfirst $.TableNameHump }}.{{ $v.Property }};
+    {{- end }}
+
+    public static final class {{ .TableNameHump }} extends SqlTable {
+        {{- range $v := .Fields }}
+        public final SqlColumn<{{ $v.JavaType }}> {{ $v.Property }} = column("{{ jquote $v.Field }}"{{ if $v.JdbcType }}, JDBCType.{{ $v.JdbcType }}{{ end }});
+        {{- end }}
+
+        public {{ .TableNameHump }}() {
+            super("{{ .TableName }}");
+        }
+    }
+}
+`
+    DynamicSqlMapperTemp = `{{- define "pkArgs" }}{{ range $i, $v := .Pks }}{{ if $i }}, {{ end }}{{ $v.JavaType }} {{ $v.Property }}_{{ end }}{{ end -}}
+{{- define "pkWhere" }}
+            c{{ range $i, $v := .Pks }}
+            .{{ if $i }}and{{ else }}where{{ end }}({{ $v.Property }}, isEqualTo({{ $v.Property }}_)){{ end }}
+{{- end -}}
+package {{ .PackagePath }}.{{ .MapperPackage }};
+
+import static {{ .PackagePath }}.{{ .MapperPackage }}.{{ .TableNameHump }}DynamicSqlSupport.*;
+import static org.mybatis.dynamic.sql.SqlBuilder.*;
+
+{{ imports (print .PackagePath "." .MapperPackage) (print .PackagePath "." .EntityPackage "." .TableNameHump) "org.apache.ibatis.annotations.Mapper" "org.apache.ibatis.annotations.SelectProvider" "org.apache.ibatis.annotations.ResultMap" "org.apache.ibatis.annotations.Results" (and (ne .EntityStyle "record") "org.apache.ibatis.annotations.Result") (and (eq .EntityStyle "record") "org.apache.ibatis.annotations.ConstructorArgs") (and (eq .EntityStyle "record") "org.apache.ibatis.annotations.Arg") (and (eq .UseGeneratedKeys 1) "org.apache.ibatis.annotations.InsertProvider") (and (eq .UseGeneratedKeys 1) "org.apache.ibatis.annotations.Options") (and (eq .UseGeneratedKeys 1) "org.mybatis.dynamic.sql.insert.render.InsertStatementProvider") (and (eq .UseGeneratedKeys 1) "org.mybatis.dynamic.sql.insert.render.MultiRowInsertStatementProvider") (and (ne .UseGeneratedKeys 1) "org.mybatis.dynamic.sql.util.mybatis3.CommonInsertMapper") "org.apache.ibatis.type.JdbcType" "org.mybatis.dynamic.sql.BasicColumn" "org.mybatis.dynamic.sql.delete.DeleteDSLCompleter" "org.mybatis.dynamic.sql.select.CountDSLCompleter" "org.mybatis.dynamic.sql.select.SelectDSLCompleter" "org.mybatis.dynamic.sql.select.render.SelectStatementProvider" "org.mybatis.dynamic.sql.update.UpdateDSL" "org.mybatis.dynamic.sql.update.UpdateDSLCompleter" "org.mybatis.dynamic.sql.update.UpdateModel" "org.mybatis.dynamic.sql.util.SqlProviderAdapter" "org.mybatis.dynamic.sql.util.mybatis3.CommonCountMapper" "org.mybatis.dynamic.sql.util.mybatis3.CommonDeleteMapper" "org.mybatis.dynamic.sql.util.mybatis3.CommonUpdateMapper" "org.mybatis.dynamic.sql.util.mybatis3.MyBatis3Utils" "java.util.Collection" "java.util.List" "java.util.Optional" .Pks }}
+
+@Mapper
+public interface {{ .TableNameHump }}Mapper extends CommonCountMapper, CommonDeleteMapper, {{ if ne .UseGeneratedKeys 1 }}CommonInsertMapper<{{ .TableNameHump }}>, {{ end }}CommonUpdateMapper {
+    BasicColumn[] selectList = BasicColumn.columnList({{ range $i, $v := .Fields }}{{ if $i }}, {{ end }}{{ $v.Property }}{{ end }});
+    {{- if eq .UseGeneratedKeys 1 }}
+
+    @InsertProvider(type = SqlProviderAdapter.class, method = "insert")
+    @Options(useGeneratedKeys = true, keyProperty = "row.{{ (index .Pks 0).Property }}")
+    int insert(InsertStatementProvider<{{ .TableNameHump }}> insertStatement);
+
+    @InsertProvider(type = SqlProviderAdapter.class, method = "insertMultiple")
+    @Options(useGeneratedKeys = true, keyProperty = "records.{{ (index .Pks 0).Property }}")
+    int insertMultiple(MultiRowInsertStatementProvider<{{ .TableNameHump }}> multipleInsertStatement);
+    {{- end }}
+
+    @SelectProvider(type = SqlProviderAdapter.class, method = "select")
+    {{- if eq .EntityStyle "record" }}
+    @Results(id = "{{ .TableNameHump }}Result")
+    @ConstructorArgs({
+        {{- range $v := .Fields }}
+        @Arg(column = "{{ $v.Field }}", javaType = {{ $v.JavaType }}.class{{ if $v.JdbcType }}, jdbcType = JdbcType.{{ $v.JdbcType }}{{ end }}{{ if eq $v.IsPk 1 }}, id = true{{ end }}),
+        {{- end }}
+    })
+    {{- else }}
+    @Results(id = "{{ .TableNameHump }}Result", value = {
+        {{- range $v := .Fields }}
+        @Result(column = "{{ $v.Field }}", property = "{{ $v.Property }}"{{ if $v.JdbcType }}, jdbcType = JdbcType.{{ $v.JdbcType }}{{ end }}{{ if eq $v.IsPk 1 }}, id = true{{ end }}),
+        {{- end }}
+    })
+    {{- end }}
+    List<{{ .TableNameHump }}> selectMany(SelectStatementProvider selectStatement);
+
+    @SelectProvider(type = SqlProviderAdapter.class, method = "select")
+    @ResultMap("{{ .TableNameHump }}Result")
+    Optional<{{ .TableNameHump }}> selectOne(SelectStatementProvider selectStatement);
+
+    default long count(CountDSLCompleter completer) {
+        return MyBatis3Utils.countFrom(this::count, {{ lcfirst .TableNameHump }}, completer);
+    }
+
+    default int delete(DeleteDSLCompleter completer) {
+        return MyBatis3Utils.deleteFrom(this::delete, {{ lcfirst .TableNameHump }}, completer);
+    }
+
+    default int insert({{ .TableNameHump }} row) {
+        return MyBatis3Utils.insert(this::insert, row, {{ lcfirst .TableNameHump }}, c ->
+            c{{ range $v := .Fields }}{{ if and (ne $v.IsGenerated 1) (not (and (eq $.UseGeneratedKeys 1) (eq $v.IsPk 1))) }}
+            .map({{ $v.Property }}).toProperty("{{ $v.Property }}"){{ end }}{{ end }}
+        );
+    }
+
+    default int insertMultiple(Collection<{{ .TableNameHump }}> records) {
+        return MyBatis3Utils.insertMultiple(this::insertMultiple, records, {{ lcfirst .TableNameHump }}, c ->
+            c{{ range $v := .Fields }}{{ if and (ne $v.IsGenerated 1) (not (and (eq $.UseGeneratedKeys 1) (eq $v.IsPk 1))) }}
+            .map({{ $v.Property }}).toProperty("{{ $v.Property }}"){{ end }}{{ end }}
+        );
+    }
+
+    default int insertSelective({{ .TableNameHump }} row) {
+        return MyBatis3Utils.insert(this::insert, row, {{ lcfirst .TableNameHump }}, c ->
+            c{{ range $v := .Fields }}{{ if ne $v.IsGenerated 1 }}
+            .map({{ $v.Property }}).toPropertyWhenPresent("{{ $v.Property }}", row::{{ accessor $.EntityStyle $v }}){{ end }}{{ end }}
+        );
+    }
+
+    default Optional<{{ .TableNameHump }}> selectOne(SelectDSLCompleter completer) {
+        return MyBatis3Utils.selectOne(this::selectOne, selectList, {{ lcfirst .TableNameHump }}, completer);
+    }
+
+    default List<{{ .TableNameHump }}> select(SelectDSLCompleter completer) {
+        return MyBatis3Utils.selectList(this::selectMany, selectList, {{ lcfirst .TableNameHump }}, completer);
+    }
+
+    default List<{{ .TableNameHump }}> selectDistinct(SelectDSLCompleter completer) {
+        return MyBatis3Utils.selectDistinct(this::selectMany, selectList, {{ lcfirst .TableNameHump }}, completer);
+    }
+
+    default int update(UpdateDSLCompleter completer) {
+        return MyBatis3Utils.update(this::update, {{ lcfirst .TableNameHump }}, completer);
+    }
+
+    static UpdateDSL<UpdateModel> updateAllColumns({{ .TableNameHump }} row, UpdateDSL<UpdateModel> dsl) {
+        return dsl{{ range $v := .Fields }}{{ if ne $v.IsGenerated 1 }}
+                .set({{ $v.Property }}).equalTo(row::{{ accessor $.EntityStyle $v }}){{ end }}{{ end }};
+    }
+
+    static UpdateDSL<UpdateModel> updateSelectiveColumns({{ .TableNameHump }} row, UpdateDSL<UpdateModel> dsl) {
+        return dsl{{ range $v := .Fields }}{{ if ne $v.IsGenerated 1 }}
+                .set({{ $v.Property }}).equalToWhenPresent(row::{{ accessor $.EntityStyle $v }}){{ end }}{{ end }};
+    }
+    {{- if .Pks }}
+
+    default Optional<{{ .TableNameHump }}> selectByPrimaryKey({{ template "pkArgs" . }}) {
+        return selectOne(c ->
+            {{- template "pkWhere" . }}
+        );
+    }
+
+    default int deleteByPrimaryKey({{ template "pkArgs" . }}) {
+        return delete(c ->
+            {{- template "pkWhere" . }}
+        );
+    }
+
+    default int updateByPrimaryKey({{ .TableNameHump }} row) {
+        return update(c ->
+            c{{ range $v := .Fields }}{{ if and (ne $v.IsPk 1) (ne $v.IsGenerated 1) }}
+            .set({{ $v.Property }}).equalTo(row::{{ accessor $.EntityStyle $v }}){{ end }}{{ end }}{{ range $i, $v := .Pks }}
+            .{{ if $i }}and{{ else }}where{{ end }}({{ $v.Property }}, isEqualTo(row::{{ accessor $.EntityStyle $v }})){{ end }}
+        );
+    }
+
+    default int updateByPrimaryKeySelective({{ .TableNameHump }} row) {
+        return update(c ->
+            c{{ range $v := .Fields }}{{ if and (ne $v.IsPk 1) (ne $v.IsGenerated 1) }}
+            .set({{ $v.Property }}).equalToWhenPresent(row::{{ accessor $.EntityStyle $v }}){{ end }}{{ end }}{{ range $i, $v := .Pks }}
+            .{{ if $i }}and{{ else }}where{{ end }}({{ $v.Property }}, isEqualTo(row::{{ accessor $.EntityStyle $v }})){{ end }}
+        );
+    }
+    {{- end }}
+
+    // @custom-begin methods
+    // @custom-end
+}
+`
+)
@@ -0,0 +1,28 @@
+package config
+
+const (
+    GapEntityTemp = `package {{ .PackagePath }}.{{ .EntityPackage }};
+
+/**
+ * {{ .TableNote }}
+ */
+public class {{ .TableNameHump }} extends {{ .TableNameHump }}BaseEntity {
+}
+`
+    GapMapperTemp = `package {{ .PackagePath }}.{{ .MapperPackage }};
+
+{{ imports (print .PackagePath "." .MapperPackage) "org.apache.ibatis.annotations.Mapper" }}
+
+@Mapper
+public interface {{ .TableNameHump }}Mapper extends {{ .TableNameHump }}BaseMapper {
+}
+`
+    GapMapperXmlTemp = `<!-- {{ .TableNote }}, 生成的语句在 Base{{ .TableNameHump }}Mapper.xml 中 -->
+<!DOCTYPE mapper
+        PUBLIC "-//mybatis.org//DTD Mapper 3.0//EN"
+        "http://mybatis.org/dtd/mybatis-3-mapper.dtd">
+
+<mapper namespace="{{ .PackagePath }}.{{ .MapperPackage }}.{{ .TableNameHump }}Mapper">
+</mapper>
+`
+)
@@ -0,0 +1,158 @@
+package config
+
+const (
+    KotlinEntityTemp = `package {{ .PackagePath }}.{{ .EntityPackage }}
+
+{{ ktimports (print .PackagePath "." .EntityPackage) "java.io.Serializable" .Fields }}
+
+/**
+ * {{ .TableNote }}
+ */
+data class {{ .TableNameHump }}(
+{{- range $v := .Fields }}
+    /**
+     * {{ $v.Comment }}
+     */
+    var {{ ktname $v.Property }}: {{ $v.KotlinType }}{{ if eq $v.KotlinNullable 1 }}?{{ end }} = {{ $v.KotlinDefault }},
+{{- end }}
+{{- range $r := .Associations }}
+    var {{ ktname $r.Property }}: {{ $r.TableNameHump }}? = null,
+{{- end }}
+{{- range $r := .Collections }}
+    var {{ ktname $r.Property }}: List<{{ $r.TableNameHump }}>? = null,
+{{- end }}
+) : Serializable {
+
+    // @custom-begin methods
+    // @custom-end
+}
+`
+    KotlinKeyTemp = `package {{ .PackagePath }}.{{ .EntityPackage }}
+
+{{ ktimports (print .PackagePath "." .EntityPackage) "java.io.Serializable" .Pks }}
+
+data class {{ .TableNameHump }}Key(
+{{- range $v := .Pks }}
+    /**
+     * {{ $v.Comment }}
+     */
+    var {{ ktname $v.Property }}: {{ $v.KotlinType }}{{ if eq $v.KotlinNullable 1 }}?{{ end }} = {{ $v.KotlinDefault }},
+{{- end }}
+) : Serializable
+`
+    KotlinMapperTemp = `package {{ .PackagePath }}.{{ .MapperPackage }}
+
+{{ ktimports (print .PackagePath "." .MapperPackage) (print .PackagePath "." .EntityPackage "." .TableNameHump) (and (eq .KeyClass 1) (print .PackagePath "." .EntityPackage "." .TableNameHump "Key")) (print .PackagePath "." .QueryPackage "." .TableNameHump "Query") "org.apache.ibatis.annotations.Mapper" (paramImport .) .Pks .UniqueIndexes .ListIndexes }}
+
+@Mapper
+interface {{ .TableNameHump }}Mapper {
+
+    fun count(query: {{ .TableNameHump }}Query): Int
+
+    fun list(query: {{ .TableNameHump }}Query): List<{{ .TableNameHump }}>
+    {{- range $idx := .UniqueIndexes }}
+
+    fun get{{ $idx.Finder }}({{ range $i, $v := $idx.Fields }}{{ if $i }}, {{ end }}@Param("{{ $v.Property }}") {{ ktname $v.Property }}: {{ $v.KotlinType }}{{ end }}): {{ $.TableNameHump }}?
+    {{- end }}
+    {{- range $idx := .ListIndexes }}
+
+    fun list{{ $idx.Finder }}({{ range $i, $v := $idx.Fields }}{{ if $i }}, {{ end }}@Param("{{ $v.Property }}") {{ ktname $v.Property }}: {{ $v.KotlinType }}{{ end }}): List<{{ $.TableNameHump }}>
+    {{- end }}
+
+    fun insert(entity: {{ .TableNameHump }}): Int
+
+    {{- if .Pks }}
+
+    fun getByPk({{ if eq .KeyClass 1 }}key: {{ .TableNameHump }}Key{{ else }}{{ range $i, $v := .Pks }}{{ if $i }}, {{ end }}@Param("{{ $v.Property }}") {{ ktname $v.Property }}: {{ $v.KotlinType }}{{ end }}{{ end }}): {{ .TableNameHump }}?
+    {{- if or .Associations .Collections }}
+
+    fun getByPkWithRelations({{ if eq .KeyClass 1 }}key: {{ .TableNameHump }}Key{{ else }}{{ range $i, $v := .Pks }}{{ if $i }}, {{ end }}@Param("{{ $v.Property }}") {{ ktname $v.Property }}: {{ $v.KotlinType }}{{ end }}{{ end }}): {{ .TableNameHump }}?
+    {{- end }}
+
+    fun update(entity: {{ .TableNameHump }}): Int
+
+    fun delete({{ if eq .KeyClass 1 }}key: {{ .TableNameHump }}Key{{ else }}{{ range $i, $v := .Pks }}{{ if $i }}, {{ end }}@Param("{{ $v.Property }}") {{ ktname $v.Property }}: {{ $v.KotlinType }}{{ end }}{{ end }}): Int
+    {{- end }}
+
+    // @custom-begin methods
+    // @custom-end
+}
+`
+    KotlinQueryTemp = `package {{ .PackagePath }}.{{ .QueryPackage }}
+
+{{ ktimports (print .PackagePath "." .QueryPackage) "java.io.Serializable" (indexed .Fields) }}
+
+open class {{ .TableNameHump }}Query : Serializable {
+    var allowSortBy: MutableMap<String, String> = initAllowSortBy()
+    var queryFields: MutableSet<String>? = initQueryFields()
+
+    var sortBy: String? = null
+        set(value) {
+            if (null != value && allowSortBy.containsKey(value)) {
+                field = allowSortBy[value]
+            }
+        }
+    var sortOrder: String? = null
+        set(value) {
+            field = if ("ASC" == value || "DESC" == value) value else "DESC"
+        }
+    var page: Int? = 1
+        get() {
+            val page = field
+            return if (null != page && page > 0) page else 1
+        }
+    var pageCnt: Int? = 20
+        get() {
+            val pageCnt = field
+            return if (null == pageCnt || pageCnt <= 0) 20 else pageCnt
+        }
+    val offset: Int
+        get() = (page!! - 1) * pageCnt!!
+    val length: Int
+        get() = pageCnt!!
+    {{- range $v := .Fields }}
+    {{- if eq $v.IsIndex 1 }}
+
+    /**
+     * {{ $v.Comment }}
+     */
+    var {{ ktname $v.Property }}: {{ $v.KotlinType }}? = null
+    {{- end }}
+    {{- end }}
+
+    protected open fun initAllowSortBy(): MutableMap<String, String> {
+        val allowSortByMap = HashMap<String, String>()
+        {{- range $v := .Pks }}
+        allowSortByMap["{{ $v.Field }}"] = "{{ $v.Field }}"
+        {{- end }}
+        return allowSortByMap
+    }
+
+    protected open fun initQueryFields(): MutableSet<String> {
+        val fieldSet = HashSet<String>()
+        {{- range $v := .Fields }}
+        fieldSet.add("{{ $v.Field }}")
+        {{- end }}
+        return fieldSet
+    }
+
+    fun nextPage() {
+        page = page!! + 1
+    }
+
+    fun prevPage() {
+        page = page!! - 1
+    }
+
+    fun addQueryField(field: String): MutableSet<String>? {
+        queryFields?.add(field)
+        return queryFields
+    }
+
+    fun removeQueryField(field: String): MutableSet<String>? {
+        queryFields?.remove(field)
+        return queryFields
+    }
+}
+`
+)
@@ -0,0 +1,121 @@
+package config
+
+const (
+    PlusEntityTemp = `package {{ .PackagePath }}.{{ .EntityPackage }};
+
+{{ imports (print .PackagePath "." .EntityPackage) "java.io.Serializable" "com.baomidou.mybatisplus.annotation.TableName" "com.baomidou.mybatisplus.annotation.TableField" (and (eq (len .Pks) 1) "com.baomidou.mybatisplus.annotation.TableId") (and (eq (len .Pks) 1) (eq (index .Pks 0).IsAutoIncrement 1) "com.baomidou.mybatisplus.annotation.IdType") (and (eq .HasLogicDelete 1) "com.baomidou.mybatisplus.annotation.TableLogic") (and (eq .HasVersion 1) "com.baomidou.mybatisplus.annotation.Version") .Fields .EntityAnnotations }}
+
+/**
+ * {{ .TableNote }}
+ */
+{{- range $a := .EntityAnnotations }}
+@{{ $a.Name }}
+{{- end }}
+@TableName("{{ .TableName }}")
+public class {{ .TableNameHump }} implements Serializable {
+    {{- range $v := .Fields }}
+
+    /**
+    * {{ $v.Comment }}
+    */
+    {{- if and (eq $v.IsPk 1) (eq (len $.Pks) 1) }}
+    @TableId(value = "{{ $v.Field }}"{{ if eq $v.IsAutoIncrement 1 }}, type = IdType.AUTO{{ end }})
+    {{- else }}
+    @TableField("{{ $v.Field }}")
+    {{- end }}
+    {{- if eq $v.IsLogicDelete 1 }}
+    @TableLogic
+    {{- end }}
+    {{- if eq $v.IsVersion 1 }}
+    @Version
+    {{- end }}
+    private {{ $v.JavaType }} {{ $v.Property }};
+    {{- end }}
+    {{- if ne .EntityStyle "lombok" }}
+    {{- range $v := .Fields }}
+
+    public void set{{ $v.PropertyN }}({{ $v.JavaType }} {{ $v.Property }}) {
+        this.{{ $v.Property }} = {{ $v.Property }};
+    }
+
+    public {{ $v.JavaType }} {{ if eq $v.JavaType "Boolean" }}is{{ else }}get{{ end }}{{ $v.PropertyN }}() {
+        return this.{{ $v.Property }};
+    }
+    {{- end }}
+    {{- end }}
+
+    // @custom-begin methods
+    // @custom-end
+}
+`
+    PlusMapperTemp = `package {{ .PackagePath }}.{{ .MapperPackage }};
+
+{{ imports (print .PackagePath "." .MapperPackage) (print .PackagePath "." .EntityPackage "." .TableNameHump) "com.baomidou.mybatisplus.core.mapper.BaseMapper" "org.apache.ibatis.annotations.Mapper" }}
+
+@Mapper
+public interface {{ .TableNameHump }}Mapper extends BaseMapper<{{ .TableNameHump }}> {
+
+    // @custom-begin methods
+    // @custom-end
+}
+`
+    PlusMapperXmlTemp = `<!-- {{ .TableNote }} -->
+<!DOCTYPE mapper
+        PUBLIC "-//mybatis.org//DTD Mapper 3.0//EN"
+        "http://mybatis.org/dtd/mybatis-3-mapper.dtd">
+
+<mapper namespace="{{ .PackagePath }}.{{ .MapperPackage }}.{{ .TableNameHump }}Mapper">
+    <resultMap id="{{ .TableNameHump }}" type="{{ .PackagePath }}.{{ .EntityPackage }}.{{ .TableNameHump }}">
+    {{- range $v := .Fields }}
+        <{{ if eq $v.IsPk 1 }}id{{ else }}result{{ end }} column="{{ $v.Field }}" property="{{ $v.Property }}" jdbcType="{{ $v.JdbcType }}" />
+    {{- end }}
+    </resultMap>
+
+    <!-- @custom-begin statements -->
+    <!-- @custom-end -->
+</mapper>
+`
+    KotlinPlusEntityTemp = `package {{ .PackagePath }}.{{ .EntityPackage }}
+
+{{ ktimports (print .PackagePath "." .EntityPackage) "java.io.Serializable" "com.baomidou.mybatisplus.annotation.TableName" "com.baomidou.mybatisplus.annotation.TableField" (and (eq (len .Pks) 1) "com.baomidou.mybatisplus.annotation.TableId") (and (eq (len .Pks) 1) (eq (index .Pks 0).IsAutoIncrement 1) "com.baomidou.mybatisplus.annotation.IdType") (and (eq .HasLogicDelete 1) "com.baomidou.mybatisplus.annotation.TableLogic") (and (eq .HasVersion 1) "com.baomidou.mybatisplus.annotation.Version") .Fields }}
+
+/**
+ * {{ .TableNote }}
+ */
+@TableName("{{ .TableName }}")
+data class {{ .TableNameHump }}(
+{{- range $v := .Fields }}
+    /**
+     * {{ $v.Comment }}
+     */
+    {{- if and (eq $v.IsPk 1) (eq (len $.Pks) 1) }}
+    @TableId(value = "{{ $v.Field }}"{{ if eq $v.IsAutoIncrement 1 }}, type = IdType.AUTO{{ end }})
+    {{- else }}
+    @TableField("{{ $v.Field }}")
+    {{- end }}
+    {{- if eq $v.IsLogicDelete 1 }}
+    @TableLogic
+    {{- end }}
+    {{- if eq $v.IsVersion 1 }}
+    @Version
+    {{- end }}
+    var {{ ktname $v.Property }}: {{ $v.KotlinType }}{{ if eq $v.KotlinNullable 1 }}?{{ end }} = {{ $v.KotlinDefault }},
+{{- end }}
+) : Serializable {
+
+    // @custom-begin methods
+    // @custom-end
+}
+`
+    KotlinPlusMapperTemp = `package {{ .PackagePath }}.{{ .MapperPackage }}
+
+{{ ktimports (print .PackagePath "." .MapperPackage) (print .PackagePath "." .EntityPackage "." .TableNameHump) "com.baomidou.mybatisplus.core.mapper.BaseMapper" "org.apache.ibatis.annotations.Mapper" }}
+
+@Mapper
+interface {{ .TableNameHump }}Mapper : BaseMapper<{{ .TableNameHump }}> {
+
+    // @custom-begin methods
+    // @custom-end
+}
+`
+)
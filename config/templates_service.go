@@ -0,0 +1,224 @@
+package config
+
+const (
+    ServiceTemp = `package {{ .PackagePath }}.{{ .ServicePackage }};
+
+{{ imports (print .PackagePath "." .ServicePackage) (print .PackagePath "." .EntityPackage "." .TableNameHump) (and (eq .KeyClass 1) (print .PackagePath "." .EntityPackage "." .TableNameHump "Key")) (print .PackagePath "." .QueryPackage "." .TableNameHump "Query") "java.util.List" .Pks }}
+
+/**
+ * {{ .TableNote }}
+ */
+public interface {{ .TableNameHump }}Service {
+
+    int count({{ .TableNameHump }}Query query);
+
+    List<{{ .TableNameHump }}> list({{ .TableNameHump }}Query query);
+
+    int insert({{ .TableNameHump }} entity);
+    {{- if .Pks }}
+
+    {{ .TableNameHump }} getByPk({{ if eq .KeyClass 1 }}{{ .TableNameHump }}Key key{{ else }}{{ range $i, $v := .Pks }}{{ if $i }}, {{ end }}{{ $v.JavaType }} {{ $v.Property }}{{ end }}{{ end }});
+
+    int update({{ .TableNameHump }} entity);
+
+    int delete({{ if eq .KeyClass 1 }}{{ .TableNameHump }}Key key{{ else }}{{ range $i, $v := .Pks }}{{ if $i }}, {{ end }}{{ $v.JavaType }} {{ $v.Property }}{{ end }}{{ end }});
+    {{- end }}
+
+    // @custom-begin methods
+    // @custom-end
+}
+`
+    ServiceImplTemp = `package {{ .PackagePath }}.{{ .ServiceImplPackage }};
+
+{{ imports (print .PackagePath "." .ServiceImplPackage) (print .PackagePath "." .EntityPackage "." .TableNameHump) (and (eq .KeyClass 1) (print .PackagePath "." .EntityPackage "." .TableNameHump "Key")) (print .PackagePath "." .QueryPackage "." .TableNameHump "Query") (print .PackagePath "." .MapperPackage "." .TableNameHump "Mapper") (print .PackagePath "." .ServicePackage "." .TableNameHump "Service") "org.springframework.stereotype.Service" "java.util.List" .Pks }}
+
+@Service
+public class {{ .TableNameHump }}ServiceImpl implements {{ .TableNameHump }}Service {
+
+    private final {{ .TableNameHump }}Mapper {{ lcfirst .TableNameHump }}Mapper;
+
+    public {{ .TableNameHump }}ServiceImpl({{ .TableNameHump }}Mapper {{ lcfirst .TableNameHump }}Mapper) {
+        this.{{ lcfirst .TableNameHump }}Mapper = {{ lcfirst .TableNameHump }}Mapper;
+    }
+
+    @Override
+    public int count({{ .TableNameHump }}Query query) {
+        return {{ lcfirst .TableNameHump }}Mapper.count(query);
+    }
+
+    @Override
+    public List<{{ .TableNameHump }}> list({{ .TableNameHump }}Query query) {
+        return {{ lcfirst .TableNameHump }}Mapper.list(query);
+    }
+
+    @Override
+    public int insert({{ .TableNameHump }} entity) {
+        return {{ lcfirst .TableNameHump }}Mapper.insert(entity);
+    }
+    {{- if .Pks }}
+
+    @Override
+    public {{ .TableNameHump }} getByPk({{ if eq .KeyClass 1 }}{{ .TableNameHump }}Key key{{ else }}{{ range $i, $v := .Pks }}{{ if $i }}, {{ end }}{{ $v.JavaType }} {{ $v.Property }}{{ end }}{{ end }}) {
+        return {{ lcfirst .TableNameHump }}Mapper.getByPk({{ if eq .KeyClass 1 }}key{{ else }}{{ range $i, $v := .Pks }}{{ if $i }}, {{ end }}{{ $v.Property }}{{ end }}{{ end }});
+    }
+
+    @Override
+    public int update({{ .TableNameHump }} entity) {
+        return {{ lcfirst .TableNameHump }}Mapper.update(entity);
+    }
+
+    @Override
+    public int delete({{ if eq .KeyClass 1 }}{{ .TableNameHump }}Key key{{ else }}{{ range $i, $v := .Pks }}{{ if $i }}, {{ end }}{{ $v.JavaType }} {{ $v.Property }}{{ end }}{{ end }}) {
+        return {{ lcfirst .TableNameHump }}Mapper.delete({{ if eq .KeyClass 1 }}key{{ else }}{{ range $i, $v := .Pks }}{{ if $i }}, {{ end }}{{ $v.Property }}{{ end }}{{ end }});
+    }
+    {{- end }}
+
+    // @custom-begin methods
+    // @custom-end
+}
+`
+    ControllerTemp = `package {{ .PackagePath }}.{{ .ControllerPackage }};
+
+{{ imports (print .PackagePath "." .ControllerPackage) (print .PackagePath "." .EntityPackage "." .TableNameHump) (and (eq .KeyClass 1) (print .PackagePath "." .EntityPackage "." .TableNameHump "Key")) (print .PackagePath "." .QueryPackage "." .TableNameHump "Query") (print .PackagePath "." .ServicePackage "." .TableNameHump "Service") "org.springframework.web.bind.annotation.GetMapping" "org.springframework.web.bind.annotation.PostMapping" "org.springframework.web.bind.annotation.RequestBody" "org.springframework.web.bind.annotation.RequestMapping" "org.springframework.web.bind.annotation.RestController" (and .Pks "org.springframework.web.bind.annotation.PutMapping") (and .Pks "org.springframework.web.bind.annotation.DeleteMapping") (and .Pks (ne .KeyClass 1) "org.springframework.web.bind.annotation.PathVariable") "java.util.List" .Pks }}
+
+/**
+ * {{ .TableNote }}
+ */
+@RestController
+@RequestMapping("/{{ lcfirst .TableNameHump }}")
+public class {{ .TableNameHump }}Controller {
+
+    private final {{ .TableNameHump }}Service {{ lcfirst .TableNameHump }}Service;
+
+    public {{ .TableNameHump }}Controller({{ .TableNameHump }}Service {{ lcfirst .TableNameHump }}Service) {
+        this.{{ lcfirst .TableNameHump }}Service = {{ lcfirst .TableNameHump }}Service;
+    }
+
+    @GetMapping
+    public List<{{ .TableNameHump }}> list({{ .TableNameHump }}Query query) {
+        return {{ lcfirst .TableNameHump }}Service.list(query);
+    }
+
+    @GetMapping("/count")
+    public int count({{ .TableNameHump }}Query query) {
+        return {{ lcfirst .TableNameHump }}Service.count(query);
+    }
+
+    @PostMapping
+    public int insert(@RequestBody {{ .TableNameHump }} entity) {
+        return {{ lcfirst .TableNameHump }}Service.insert(entity);
+    }
+    {{- if .Pks }}
+
+    @GetMapping("{{ range $v := .Pks }}/{{ "{" }}{{ $v.Property }}{{ "}" }}{{ end }}")
+    public {{ .TableNameHump }} get({{ if eq .KeyClass 1 }}{{ .TableNameHump }}Key key{{ else }}{{ range $i, $v := .Pks }}{{ if $i }}, {{ end }}@PathVariable("{{ $v.Property }}") {{ $v.JavaType }} {{ $v.Property }}{{ end }}{{ end }}) {
+        return {{ lcfirst .TableNameHump }}Service.getByPk({{ if eq .KeyClass 1 }}key{{ else }}{{ range $i, $v := .Pks }}{{ if $i }}, {{ end }}{{ $v.Property }}{{ end }}{{ end }});
+    }
+
+    @PutMapping
+    public int update(@RequestBody {{ .TableNameHump }} entity) {
+        return {{ lcfirst .TableNameHump }}Service.update(entity);
+    }
+
+    @DeleteMapping("{{ range $v := .Pks }}/{{ "{" }}{{ $v.Property }}{{ "}" }}{{ end }}")
+    public int delete({{ if eq .KeyClass 1 }}{{ .TableNameHump }}Key key{{ else }}{{ range $i, $v := .Pks }}{{ if $i }}, {{ end }}@PathVariable("{{ $v.Property }}") {{ $v.JavaType }} {{ $v.Property }}{{ end }}{{ end }}) {
+        return {{ lcfirst .TableNameHump }}Service.delete({{ if eq .KeyClass 1 }}key{{ else }}{{ range $i, $v := .Pks }}{{ if $i }}, {{ end }}{{ $v.Property }}{{ end }}{{ end }});
+    }
+    {{- end }}
+
+    // @custom-begin methods
+    // @custom-end
+}
+`
+    KotlinServiceTemp = `package {{ .PackagePath }}.{{ .ServicePackage }}
+
+{{ ktimports (print .PackagePath "." .ServicePackage) (print .PackagePath "." .EntityPackage "." .TableNameHump) (and (eq .KeyClass 1) (print .PackagePath "." .EntityPackage "." .TableNameHump "Key")) (print .PackagePath "." .QueryPackage "." .TableNameHump "Query") .Pks }}
+
+/**
+ * {{ .TableNote }}
+ */
+interface {{ .TableNameHump }}Service {
+
+    fun count(query: {{ .TableNameHump }}Query): Int
+
+    fun list(query: {{ .TableNameHump }}Query): List<{{ .TableNameHump }}>
+
+    fun insert(entity: {{ .TableNameHump }}): Int
+    {{- if .Pks }}
+
+    fun getByPk({{ if eq .KeyClass 1 }}key: {{ .TableNameHump }}Key{{ else }}{{ range $i, $v := .Pks }}{{ if $i }}, {{ end }}{{ ktname $v.Property }}: {{ $v.KotlinType }}{{ end }}{{ end }}): {{ .TableNameHump }}?
+
+    fun update(entity: {{ .TableNameHump }}): Int
+
+    fun delete({{ if eq .KeyClass 1 }}key: {{ .TableNameHump }}Key{{ else }}{{ range $i, $v := .Pks }}{{ if $i }}, {{ end }}{{ ktname $v.Property }}: {{ $v.KotlinType }}{{ end }}{{ end }}): Int
+    {{- end }}
+
+    // @custom-begin methods
+    // @custom-end
+}
+`
+    KotlinServiceImplTemp = `package {{ .PackagePath }}.{{ .ServiceImplPackage }}
+
+{{ ktimports (print .PackagePath "." .ServiceImplPackage) (print .PackagePath "." .EntityPackage "." .TableNameHump) (and (eq .KeyClass 1) (print .PackagePath "." .EntityPackage "." .TableNameHump "Key")) (print .PackagePath "." .QueryPackage "." .TableNameHump "Query") (print .PackagePath "." .MapperPackage "." .TableNameHump "Mapper") (print .PackagePath "." .ServicePackage "." .TableNameHump "Service") "org.springframework.stereotype.Service" .Pks }}
+
+@Service
+class {{ .TableNameHump }}ServiceImpl(private val {{ lcfirst .TableNameHump }}Mapper: {{ .TableNameHump }}Mapper) : {{ .TableNameHump }}Service {
+
+    override fun count(query: {{ .TableNameHump }}Query): Int = {{ lcfirst .TableNameHump }}Mapper.count(query)
+
+    override fun list(query: {{ .TableNameHump }}Query): List<{{ .TableNameHump }}> = {{ lcfirst .TableNameHump }}Mapper.list(query)
+
+    override fun insert(entity: {{ .TableNameHump }}): Int = {{ lcfirst .TableNameHump }}Mapper.insert(entity)
+    {{- if .Pks }}
+
+    override fun getByPk({{ if eq .KeyClass 1 }}key: {{ .TableNameHump }}Key{{ else }}{{ range $i, $v := .Pks }}{{ if $i }}, {{ end }}{{ ktname $v.Property }}: {{ $v.KotlinType }}{{ end }}{{ end }}): {{ .TableNameHump }}? =
+        {{ lcfirst .TableNameHump }}Mapper.getByPk({{ if eq .KeyClass 1 }}key{{ else }}{{ range $i, $v := .Pks }}{{ if $i }}, {{ end }}{{ ktname $v.Property }}{{ end }}{{ end }})
+
+    override fun update(entity: {{ .TableNameHump }}): Int = {{ lcfirst .TableNameHump }}Mapper.update(entity)
+
+    override fun delete({{ if eq .KeyClass 1 }}key: {{ .TableNameHump }}Key{{ else }}{{ range $i, $v := .Pks }}{{ if $i }}, {{ end }}{{ ktname $v.Property }}: {{ $v.KotlinType }}{{ end }}{{ end }}): Int =
+        {{ lcfirst .TableNameHump }}Mapper.delete({{ if eq .KeyClass 1 }}key{{ else }}{{ range $i, $v := .Pks }}{{ if $i }}, {{ end }}{{ ktname $v.Property }}{{ end }}{{ end }})
+    {{- end }}
+
+    // @custom-begin methods
+    // @custom-end
+}
+`
+    KotlinControllerTemp = `package {{ .PackagePath }}.{{ .ControllerPackage }}
+
+{{ ktimports (print .PackagePath "." .ControllerPackage) (print .PackagePath "." .EntityPackage "." .TableNameHump) (and (eq .KeyClass 1) (print .PackagePath "." .EntityPackage "." .TableNameHump "Key")) (print .PackagePath "." .QueryPackage "." .TableNameHump "Query") (print .PackagePath "." .ServicePackage "." .TableNameHump "Service") "org.springframework.web.bind.annotation.GetMapping" "org.springframework.web.bind.annotation.PostMapping" "org.springframework.web.bind.annotation.RequestBody" "org.springframework.web.bind.annotation.RequestMapping" "org.springframework.web.bind.annotation.RestController" (and .Pks "org.springframework.web.bind.annotation.PutMapping") (and .Pks "org.springframework.web.bind.annotation.DeleteMapping") (and .Pks (ne .KeyClass 1) "org.springframework.web.bind.annotation.PathVariable") .Pks }}
+
+/**
+ * {{ .TableNote }}
+ */
+@RestController
+@RequestMapping("/{{ lcfirst .TableNameHump }}")
+class {{ .TableNameHump }}Controller(private val {{ lcfirst .TableNameHump }}Service: {{ .TableNameHump }}Service) {
+
+    @GetMapping
+    fun list(query: {{ .TableNameHump }}Query): List<{{ .TableNameHump }}> = {{ lcfirst .TableNameHump }}Service.list(query)
+
+    @GetMapping("/count")
+    fun count(query: {{ .TableNameHump }}Query): Int = {{ lcfirst .TableNameHump }}Service.count(query)
+
+    @PostMapping
+    fun insert(@RequestBody entity: {{ .TableNameHump }}): Int = {{ lcfirst .TableNameHump }}Service.insert(entity)
+    {{- if .Pks }}
+
+    @GetMapping("{{ range $v := .Pks }}/{{ "{" }}{{ $v.Property }}{{ "}" }}{{ end }}")
+    fun get({{ if eq .KeyClass 1 }}key: {{ .TableNameHump }}Key{{ else }}{{ range $i, $v := .Pks }}{{ if $i }}, {{ end }}@PathVariable("{{ $v.Property }}") {{ ktname $v.Property }}: {{ $v.KotlinType }}{{ end }}{{ end }}): {{ .TableNameHump }}? =
+        {{ lcfirst .TableNameHump }}Service.getByPk({{ if eq .KeyClass 1 }}key{{ else }}{{ range $i, $v := .Pks }}{{ if $i }}, {{ end }}{{ ktname $v.Property }}{{ end }}{{ end }})
+
+    @PutMapping
+    fun update(@RequestBody entity: {{ .TableNameHump }}): Int = {{ lcfirst .TableNameHump }}Service.update(entity)
+
+    @DeleteMapping("{{ range $v := .Pks }}/{{ "{" }}{{ $v.Property }}{{ "}" }}{{ end }}")
+    fun delete({{ if eq .KeyClass 1 }}key: {{ .TableNameHump }}Key{{ else }}{{ range $i, $v := .Pks }}{{ if $i }}, {{ end }}@PathVariable("{{ $v.Property }}") {{ ktname $v.Property }}: {{ $v.KotlinType }}{{ end }}{{ end }}): Int =
+        {{ lcfirst .TableNameHump }}Service.delete({{ if eq .KeyClass 1 }}key{{ else }}{{ range $i, $v := .Pks }}{{ if $i }}, {{ end }}{{ ktname $v.Property }}{{ end }}{{ end }})
+    {{- end }}
+
+    // @custom-begin methods
+    // @custom-end
+}
+`
+)
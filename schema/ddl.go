@@ -0,0 +1,1155 @@
+package schema
+
+import (
+    "errors"
+    "fmt"
+    "strconv"
+    "strings"
+)
+
+const (
+    tokenIdent  = iota // 关键字或未加引号的标识符
+    tokenQuoted        // 反引号或双引号包裹的标识符
+    tokenString        // 单引号字符串
+    tokenNumber
+    tokenSymbol
+)
+
+type token struct {
+    kind  int
+    value string
+}
+
+// is 判断是否为指定关键字或符号, 关键字不区分大小写
+func (t token) is(s string) bool {
+    if tokenIdent == t.kind {
+        return strings.EqualFold(t.value, s)
+    }
+    return tokenSymbol == t.kind && t.value == s
+}
+
+type ddlTable struct {
+    table       Table
+    columns     []Column
+    indexes     []Index
+    foreignKeys []ForeignKey
+}
+
+type ddlProvider struct {
+    tables    []*ddlTable
+    temporary map[string]bool // 跳过的临时表
+}
+
+// NewDdlProvider 解析 MySQL 的 CREATE TABLE 语句, 不需要连接数据库
+func NewDdlProvider(ddl string) (Provider, error) {
+    tokens, err := tokenize(ddl)
+    if nil != err {
+        return nil, err
+    }
+    p := &ddlProvider{temporary: map[string]bool{}}
+    for _, stmt := range splitStatements(tokens) {
+        if 3 > len(stmt) {
+            continue
+        }
+        if stmt[0].is("drop") && stmt[1].is("table") {
+            p.drop(stmt[2:])
+            continue
+        }
+        if stmt[0].is("drop") && stmt[1].is("index") {
+            if err := p.dropIndex(stmt[2:]); nil != err {
+                return nil, err
+            }
+            continue
+        }
+        if stmt[0].is("rename") && stmt[1].is("table") {
+            if err := p.rename(stmt[2:]); nil != err {
+                return nil, err
+            }
+            continue
+        }
+        if stmt[0].is("alter") && stmt[1].is("table") {
+            if err := p.alter(stmt[2:]); nil != err {
+                return nil, err
+            }
+            continue
+        }
+        if !stmt[0].is("create") {
+            continue
+        }
+        i := 1
+        temporary := stmt[i].is("temporary")
+        if temporary {
+            i++
+        }
+        if stmt[i].is("unique") || stmt[i].is("index") {
+            p.createIndex(stmt[i:])
+            continue
+        }
+        if !stmt[i].is("table") {
+            continue
+        }
+        table, err := p.create(stmt[i+1:])
+        if nil != err {
+            return nil, err
+        }
+        if temporary { // 临时表只在会话中存在, 不生成代码, 之后对它的修改也忽略
+            p.temporary[table.table.Name] = true
+            continue
+        }
+        p.drop([]token{{kind: tokenQuoted, value: table.table.Name}})
+        p.tables = append(p.tables, table)
+    }
+    return p, nil
+}
+
+// drop 处理 DROP TABLE [IF EXISTS] a, b
+func (p *ddlProvider) drop(stmt []token) {
+    if 2 <= len(stmt) && stmt[0].is("if") && stmt[1].is("exists") {
+        stmt = stmt[2:]
+    }
+    for _, v := range stmt {
+        if tokenIdent != v.kind && tokenQuoted != v.kind {
+            continue
+        }
+        for i, t := range p.tables {
+            if t.table.Name == v.value {
+                p.tables = append(p.tables[0:i], p.tables[i+1:]...)
+                break
+            }
+        }
+    }
+}
+
+// alter 处理 ALTER TABLE t ..., 支持 ADD, DROP, MODIFY, CHANGE, RENAME, ALTER COLUMN 和表注释,
+// 不影响表结构的表选项忽略, 其他操作和不存在的表返回错误, 避免生成的代码与实际的表结构不一致
+func (p *ddlProvider) alter(stmt []token) error {
+    r := &tokenReader{tokens: stmt}
+    name := r.qualifiedName()
+    if p.temporary[name] {
+        return nil
+    }
+    t, err := p.find(name)
+    if nil != err {
+        return fmt.Errorf("invalid alter table statement, %v", err)
+    }
+    for !r.eof() {
+        spec := r.group()
+        r.next() // ,
+        if 0 == len(spec) {
+            continue
+        }
+        if err := p.alterSpec(t, spec); nil != err {
+            return fmt.Errorf("invalid alter table statement of %s, %v", name, err)
+        }
+    }
+    t.refreshColumns()
+    return nil
+}
+
+// 不影响表结构的表选项
+var ignoredTableOptions = []string{"engine", "auto_increment", "default", "character", "charset", "collate",
+    "row_format", "algorithm", "lock", "key_block_size", "stats_persistent", "pack_keys", "checksum", "force"}
+
+// alterSpec 处理 ALTER TABLE 中逗号分隔的单个操作
+func (p *ddlProvider) alterSpec(t *ddlTable, spec []token) error {
+    r := &tokenReader{tokens: spec}
+    op := r.next()
+    switch {
+    case op.is("add"):
+        if r.peekIs("column") {
+            r.next()
+        }
+        if r.eof() {
+            return errors.New("the definition after ADD is missing")
+        }
+        if r.peekIs("(") { // ADD (a int, b int)
+            inner := &tokenReader{tokens: r.group()}
+            for !inner.eof() {
+                if def := inner.group(); 0 < len(def) {
+                    if err := t.parseDefinition(def); nil != err {
+                        return err
+                    }
+                }
+                inner.next() // ,
+            }
+            return nil
+        }
+        columns := len(t.columns)
+        def := spec[r.pos:]
+        if err := t.parseDefinition(def); nil != err {
+            return err
+        }
+        if columns < len(t.columns) {
+            return t.placeColumn(t.columns[len(t.columns)-1].Name, def)
+        }
+        return nil
+    case op.is("drop"):
+        switch {
+        case r.peekIs("primary"):
+            return t.dropIndex("PRIMARY")
+        case r.peekIs("index"), r.peekIs("key"):
+            r.next()
+            return t.dropIndex(r.next().value)
+        case r.peekIs("foreign"):
+            r.next()
+            r.next() // key
+            return t.dropForeignKey(r.next().value)
+        case r.peekIs("check"):
+            return nil
+        case r.peekIs("constraint"): // 可能是外键、唯一索引或检查约束
+            r.next()
+            name := r.next().value
+            if err := t.dropForeignKey(name); nil != err {
+                t.dropIndex(name) // 不是外键时可能是唯一索引或检查约束
+            }
+            return nil
+        }
+        if r.peekIs("column") {
+            r.next()
+        }
+        if r.eof() {
+            return errors.New("the column after DROP is missing")
+        }
+        return t.dropColumn(r.next().value)
+    case op.is("modify"), op.is("change"):
+        if r.peekIs("column") {
+            r.next()
+        }
+        if r.eof() {
+            return fmt.Errorf("the definition after %s is missing", strings.ToUpper(op.value))
+        }
+        old := r.tokens[r.pos].value
+        if op.is("change") {
+            old = r.next().value
+        }
+        return t.replaceColumn(old, spec[r.pos:])
+    case op.is("rename"):
+        switch {
+        case r.peekIs("column"):
+            r.next()
+            old := r.next().value
+            if !r.next().is("to") || r.eof() {
+                return errors.New("invalid RENAME COLUMN")
+            }
+            return t.renameColumn(old, r.next().value)
+        case r.peekIs("index"), r.peekIs("key"):
+            r.next()
+            old := r.next().value
+            if !r.next().is("to") || r.eof() {
+                return errors.New("invalid RENAME INDEX")
+            }
+            return t.renameIndex(old, r.next().value)
+        }
+        if r.peekIs("to") || r.peekIs("as") {
+            r.next()
+        }
+        if r.eof() {
+            return errors.New("the new table name after RENAME is missing")
+        }
+        p.renameTable(t.table.Name, r.qualifiedName())
+        return nil
+    case op.is("alter"):
+        if r.peekIs("column") {
+            r.next()
+        }
+        name := r.next().value
+        column := t.column(name)
+        if nil == column {
+            return fmt.Errorf("column %s not found", name)
+        }
+        switch action := r.next(); {
+        case action.is("set") && r.peekIs("default"):
+            r.next()
+            column.Default = r.defaultValue()
+        case action.is("drop") && r.peekIs("default"):
+            column.Default = nil
+        case action.is("set") && (r.peekIs("visible") || r.peekIs("invisible")):
+        default:
+            return fmt.Errorf("%s is not supported", joinTokens(spec))
+        }
+        return nil
+    case op.is("comment"):
+        if r.peekIs("=") {
+            r.next()
+        }
+        t.table.Comment = r.next().value
+        return nil
+    }
+    for _, v := range ignoredTableOptions {
+        if op.is(v) {
+            return nil
+        }
+    }
+    return fmt.Errorf("%s is not supported", joinTokens(spec))
+}
+
+// column 按名字查找列, 不区分大小写
+func (t *ddlTable) column(name string) *Column {
+    for i, v := range t.columns {
+        if strings.EqualFold(v.Name, name) {
+            return &t.columns[i]
+        }
+    }
+    return nil
+}
+
+func (t *ddlTable) columnIndex(name string) int {
+    for i, v := range t.columns {
+        if strings.EqualFold(v.Name, name) {
+            return i
+        }
+    }
+    return -1
+}
+
+// placeColumn 处理列定义末尾的 FIRST 或 AFTER col
+func (t *ddlTable) placeColumn(name string, def []token) error {
+    depth := 0
+    for i := 2; i < len(def); i++ { // 跳过列名和类型
+        switch {
+        case def[i].is("("):
+            depth++
+        case def[i].is(")"):
+            depth--
+        case 0 == depth && def[i].is("first"):
+            t.moveColumn(name, 0)
+        case 0 == depth && def[i].is("after") && i+1 < len(def):
+            after := t.columnIndex(def[i+1].value)
+            if -1 == after {
+                return fmt.Errorf("column %s not found", def[i+1].value)
+            }
+            if after < t.columnIndex(name) { // 向前移动时插入到 after 之后, 向后移动时删除原来的列后 after 前移了一位
+                after++
+            }
+            t.moveColumn(name, after)
+        }
+    }
+    return nil
+}
+
+// moveColumn 将列移动到 to 的位置
+func (t *ddlTable) moveColumn(name string, to int) {
+    from := t.columnIndex(name)
+    column := t.columns[from]
+    t.columns = append(t.columns[0:from], t.columns[from+1:]...)
+    t.columns = append(t.columns[0:to], append([]Column{column}, t.columns[to:]...)...)
+}
+
+// replaceColumn 处理 MODIFY 和 CHANGE, 新的列定义替换原来的列, 位置不变
+func (t *ddlTable) replaceColumn(old string, def []token) error {
+    i := t.columnIndex(old)
+    if -1 == i {
+        return fmt.Errorf("column %s not found", old)
+    }
+    old = t.columns[i].Name
+    t.columns = append(t.columns[0:i], t.columns[i+1:]...)
+    if err := t.parseColumn(&tokenReader{tokens: def}); nil != err {
+        return err
+    }
+    name := t.columns[len(t.columns)-1].Name
+    t.moveColumn(name, i)
+    t.renameKeyColumn(old, name)
+    return t.placeColumn(name, def)
+}
+
+// renameColumn 重命名列
+func (t *ddlTable) renameColumn(old, name string) error {
+    column := t.column(old)
+    if nil == column {
+        return fmt.Errorf("column %s not found", old)
+    }
+    column.Name = name
+    t.renameKeyColumn(old, name)
+    return nil
+}
+
+// renameKeyColumn 修改索引和外键中的列名
+func (t *ddlTable) renameKeyColumn(old, name string) {
+    for _, v := range t.indexes {
+        replaceName(v.Columns, old, name)
+    }
+    for _, v := range t.foreignKeys {
+        replaceName(v.Columns, old, name)
+    }
+}
+
+// dropColumn 删除列, 同时从索引中删除该列, 索引中没有列时删除索引
+func (t *ddlTable) dropColumn(name string) error {
+    i := t.columnIndex(name)
+    if -1 == i {
+        return fmt.Errorf("column %s not found", name)
+    }
+    t.columns = append(t.columns[0:i], t.columns[i+1:]...)
+    var indexes []Index
+    for _, v := range t.indexes {
+        var columns []string
+        for _, c := range v.Columns {
+            if !strings.EqualFold(c, name) {
+                columns = append(columns, c)
+            }
+        }
+        if 0 < len(columns) {
+            v.Columns = columns
+            indexes = append(indexes, v)
+        }
+    }
+    t.indexes = indexes
+    var fks []ForeignKey
+    for _, v := range t.foreignKeys {
+        if !containsFold(v.Columns, name) {
+            fks = append(fks, v)
+        }
+    }
+    t.foreignKeys = fks
+    return nil
+}
+
+func (t *ddlTable) dropIndex(name string) error {
+    for i, v := range t.indexes {
+        if strings.EqualFold(v.Name, name) {
+            t.indexes = append(t.indexes[0:i], t.indexes[i+1:]...)
+            return nil
+        }
+    }
+    return fmt.Errorf("index %s not found", name)
+}
+
+func (t *ddlTable) renameIndex(old, name string) error {
+    for i, v := range t.indexes {
+        if strings.EqualFold(v.Name, old) {
+            t.indexes[i].Name = name
+            return nil
+        }
+    }
+    return fmt.Errorf("index %s not found", old)
+}
+
+func (t *ddlTable) dropForeignKey(name string) error {
+    for i, v := range t.foreignKeys {
+        if strings.EqualFold(v.Name, name) {
+            t.foreignKeys = append(t.foreignKeys[0:i], t.foreignKeys[i+1:]...)
+            return nil
+        }
+    }
+    return fmt.Errorf("foreign key %s not found", name)
+}
+
+// refreshColumns 表结构变化后重新计算列的位置和 COLUMN_KEY
+func (t *ddlTable) refreshColumns() {
+    pks := primaryKeys(t.indexes)
+    for i, v := range t.columns {
+        t.columns[i].Position = i + 1
+        t.columns[i].Key = columnKey(v.Name, t.indexes)
+        if contains(pks, v.Name) {
+            t.columns[i].Nullable = false
+        }
+    }
+}
+
+// renameTable 重命名表, 同时修改外键中的表名
+func (p *ddlProvider) renameTable(old, name string) {
+    for _, t := range p.tables {
+        if t.table.Name == old {
+            t.table.Name = name
+        }
+        for i, fk := range t.foreignKeys {
+            if fk.Table == old {
+                t.foreignKeys[i].Table = name
+            }
+            if fk.RefTable == old {
+                t.foreignKeys[i].RefTable = name
+            }
+        }
+    }
+}
+
+// rename 处理 RENAME TABLE a TO b, c TO d
+func (p *ddlProvider) rename(stmt []token) error {
+    r := &tokenReader{tokens: stmt}
+    for !r.eof() {
+        old := r.qualifiedName()
+        if !r.next().is("to") || r.eof() {
+            return fmt.Errorf("invalid rename table statement of %s", old)
+        }
+        name := r.qualifiedName()
+        if _, err := p.find(old); nil != err {
+            return fmt.Errorf("invalid rename table statement, %v", err)
+        }
+        p.renameTable(old, name)
+        if r.peekIs(",") {
+            r.next()
+        }
+    }
+    return nil
+}
+
+// dropIndex 处理 DROP INDEX name ON table
+func (p *ddlProvider) dropIndex(stmt []token) error {
+    r := &tokenReader{tokens: stmt}
+    name := r.next().value
+    if !r.next().is("on") {
+        return fmt.Errorf("invalid drop index statement of %s", name)
+    }
+    table := r.qualifiedName()
+    if p.temporary[table] {
+        return nil
+    }
+    t, err := p.find(table)
+    if nil != err {
+        return fmt.Errorf("invalid drop index statement, %v", err)
+    }
+    if err := t.dropIndex(name); nil != err {
+        return fmt.Errorf("invalid drop index statement, %v", err)
+    }
+    t.refreshColumns()
+    return nil
+}
+
+func replaceName(names []string, old, name string) {
+    for i, v := range names {
+        if strings.EqualFold(v, old) {
+            names[i] = name
+        }
+    }
+}
+
+func containsFold(names []string, name string) bool {
+    for _, v := range names {
+        if strings.EqualFold(v, name) {
+            return true
+        }
+    }
+    return false
+}
+
+// createIndex 处理 CREATE [UNIQUE] INDEX name ON table (a, b)
+func (p *ddlProvider) createIndex(stmt []token) {
+    r := &tokenReader{tokens: stmt}
+    index := Index{Unique: r.next().is("unique")}
+    if index.Unique {
+        r.next() // index
+    }
+    index.Name = r.next().value
+    if !r.next().is("on") {
+        return
+    }
+    t, err := p.find(r.qualifiedName())
+    if nil != err {
+        return
+    }
+    index.Columns = r.indexColumns()
+    t.indexes = append(t.indexes, index)
+    for i, v := range t.columns {
+        t.columns[i].Key = columnKey(v.Name, t.indexes)
+    }
+}
+
+func (p *ddlProvider) find(name string) (*ddlTable, error) {
+    for _, v := range p.tables {
+        if v.table.Name == name {
+            return v, nil
+        }
+    }
+    return nil, fmt.Errorf("table %s not found in ddl", name)
+}
+
+func (p *ddlProvider) Tables(names []string) ([]Table, error) {
+    var tables []Table
+    for _, v := range p.tables {
+        if 0 < len(names) && !contains(names, v.table.Name) {
+            continue
+        }
+        tables = append(tables, v.table)
+    }
+    return tables, nil
+}
+
+func (p *ddlProvider) Columns(table string) ([]Column, error) {
+    t, err := p.find(table)
+    if nil != err {
+        return nil, err
+    }
+    return t.columns, nil
+}
+
+func (p *ddlProvider) Indexes(table string) ([]Index, error) {
+    t, err := p.find(table)
+    if nil != err {
+        return nil, err
+    }
+    return t.indexes, nil
+}
+
+func (p *ddlProvider) PrimaryKeys(table string) ([]string, error) {
+    t, err := p.find(table)
+    if nil != err {
+        return nil, err
+    }
+    return primaryKeys(t.indexes), nil
+}
+
+func (p *ddlProvider) ForeignKeys(table string) ([]ForeignKey, error) {
+    var fks []ForeignKey
+    for _, v := range p.tables {
+        for _, fk := range v.foreignKeys {
+            if fk.Table == table || fk.RefTable == table {
+                fks = append(fks, fk)
+            }
+        }
+    }
+    return fks, nil
+}
+
+func (p *ddlProvider) Close() error {
+    return nil
+}
+
+// create 处理 CREATE TABLE 之后的部分, CREATE TABLE t LIKE s 与 CREATE TABLE t (LIKE s) 复制 s 的列和索引
+func (p *ddlProvider) create(stmt []token) (*ddlTable, error) {
+    r := &tokenReader{tokens: stmt}
+    if r.peekIs("if") {
+        r.next()
+        r.next() // not
+        r.next() // exists
+    }
+    name := r.qualifiedName()
+    parenthesized := r.peekIs("(")
+    if parenthesized {
+        r.next()
+    }
+    if "" == name || !r.peekIs("like") {
+        return parseCreateTable(stmt)
+    }
+    r.next()
+    source := r.qualifiedName()
+    if parenthesized && !r.next().is(")") {
+        return nil, fmt.Errorf("invalid create table statement of %s", name)
+    }
+    t, err := p.find(source)
+    if nil != err {
+        return nil, fmt.Errorf("invalid create table statement of %s, %v", name, err)
+    }
+    return t.copy(name), nil
+}
+
+// copy 复制表结构, 与 MySQL 一致, 外键不会复制
+func (t *ddlTable) copy(name string) *ddlTable {
+    table := &ddlTable{table: Table{Name: name, Comment: t.table.Comment}}
+    table.columns = append(table.columns, t.columns...)
+    for _, v := range t.indexes {
+        v.Columns = append([]string(nil), v.Columns...)
+        table.indexes = append(table.indexes, v)
+    }
+    return table
+}
+
+// parseCreateTable 解析 CREATE TABLE 之后的部分
+func parseCreateTable(stmt []token) (*ddlTable, error) {
+    r := &tokenReader{tokens: stmt}
+    if r.peekIs("if") {
+        r.next()
+        r.next() // not
+        r.next() // exists
+    }
+    table := &ddlTable{table: Table{Name: r.qualifiedName()}}
+    if "" == table.table.Name {
+        return nil, errors.New("invalid create table statement, table name not found")
+    }
+    if !r.next().is("(") {
+        return nil, fmt.Errorf("invalid create table statement of %s", table.table.Name)
+    }
+    for !r.eof() {
+        def := r.group()
+        if 0 < len(def) {
+            if err := table.parseDefinition(def); nil != err {
+                return nil, err
+            }
+        }
+        if sep := r.next(); sep.is(")") {
+            break
+        }
+    }
+    // 表选项, 只关心 COMMENT
+    for !r.eof() {
+        if r.next().is("comment") {
+            if r.peekIs("=") {
+                r.next()
+            }
+            table.table.Comment = r.next().value
+        }
+    }
+    pks := primaryKeys(table.indexes)
+    for i, v := range table.columns {
+        table.columns[i].Key = columnKey(v.Name, table.indexes)
+        if contains(pks, v.Name) { // 主键列总是 NOT NULL
+            table.columns[i].Nullable = false
+        }
+    }
+    return table, nil
+}
+
+// parseDefinition 解析括号内的单个列或索引定义
+func (t *ddlTable) parseDefinition(def []token) error {
+    r := &tokenReader{tokens: def}
+    constraint := ""
+    if r.peekIs("constraint") {
+        r.next()
+        if !r.peekIs("primary") && !r.peekIs("unique") && !r.peekIs("foreign") && !r.peekIs("check") {
+            constraint = r.next().value
+        }
+    }
+    switch {
+    case r.peekIs("primary"):
+        r.next()
+        r.next() // key
+        t.indexes = append(t.indexes, Index{Name: "PRIMARY", Unique: true, Primary: true, Columns: r.indexColumns()})
+    case r.peekIs("unique"):
+        r.next()
+        if r.peekIs("key") || r.peekIs("index") {
+            r.next()
+        }
+        name := ""
+        if !r.peekIs("(") {
+            name = r.next().value
+        }
+        if "" == name {
+            name = constraint
+        }
+        columns := r.indexColumns()
+        if "" == name && 0 < len(columns) {
+            name = columns[0]
+        }
+        t.indexes = append(t.indexes, Index{Name: name, Unique: true, Columns: columns})
+    case r.peekIs("key"), r.peekIs("index"), r.peekIs("fulltext"), r.peekIs("spatial"):
+        if first := r.next(); first.is("fulltext") || first.is("spatial") {
+            if r.peekIs("key") || r.peekIs("index") {
+                r.next()
+            }
+        }
+        name := ""
+        if !r.peekIs("(") {
+            name = r.next().value
+        }
+        columns := r.indexColumns()
+        if "" == name && 0 < len(columns) {
+            name = columns[0]
+        }
+        t.indexes = append(t.indexes, Index{Name: name, Columns: columns})
+    case r.peekIs("foreign"):
+        r.next()
+        r.next() // key
+        if !r.peekIs("(") && "" == constraint {
+            constraint = r.next().value
+        }
+        t.parseForeignKey(constraint, r)
+    case r.peekIs("check"):
+        // 检查约束不影响列定义
+    default:
+        return t.parseColumn(r)
+    }
+    return nil
+}
+
+// parseForeignKey 解析 (a, b) REFERENCES t (c, d) [ON DELETE ...] [ON UPDATE ...]
+func (t *ddlTable) parseForeignKey(name string, r *tokenReader) {
+    fk := ForeignKey{Name: name, Table: t.table.Name, Columns: r.indexColumns(), OnUpdate: "NO ACTION", OnDelete: "NO ACTION"}
+    if !r.next().is("references") {
+        return
+    }
+    fk.RefTable = r.qualifiedName()
+    fk.RefColumns = r.indexColumns()
+    for !r.eof() {
+        if !r.next().is("on") {
+            continue
+        }
+        action := r.next()
+        rule := strings.ToUpper(r.next().value)
+        if "SET" == rule || "NO" == rule {
+            rule += " " + strings.ToUpper(r.next().value)
+        }
+        if action.is("update") {
+            fk.OnUpdate = rule
+        } else {
+            fk.OnDelete = rule
+        }
+    }
+    if "" == fk.Name {
+        fk.Name = fmt.Sprintf("%s_ibfk_%d", t.table.Name, len(t.foreignKeys)+1)
+    }
+    t.foreignKeys = append(t.foreignKeys, fk)
+    // 与 MySQL 一致, 外键列没有索引时自动创建索引
+    for _, v := range t.indexes {
+        if len(v.Columns) >= len(fk.Columns) && strings.Join(v.Columns[0:len(fk.Columns)], ",") == strings.Join(fk.Columns, ",") {
+            return
+        }
+    }
+    t.indexes = append(t.indexes, Index{Name: fk.Name, Columns: fk.Columns})
+}
+
+func (t *ddlTable) parseColumn(r *tokenReader) error {
+    column := Column{Name: r.next().value, Nullable: true, Position: len(t.columns) + 1}
+    dataType := r.next()
+    if tokenIdent != dataType.kind {
+        return fmt.Errorf("invalid definition of column %s.%s", t.table.Name, column.Name)
+    }
+    column.DataType = strings.ToLower(dataType.value)
+    column.ColumnType = column.DataType
+    var args []token
+    if r.peekIs("(") {
+        args = r.group()
+        column.ColumnType += "(" + joinTokens(args) + ")"
+    }
+    column.MaxLength, column.Precision, column.Scale = mysqlTypeSize(column.DataType, args)
+    var extra []string
+    for !r.eof() {
+        tok := r.next()
+        switch {
+        case tok.is("unsigned"), tok.is("zerofill"):
+            column.ColumnType += " " + strings.ToLower(tok.value)
+        case tok.is("not"):
+            if r.peekIs("null") {
+                r.next()
+                column.Nullable = false
+            }
+        case tok.is("auto_increment"):
+            extra = append(extra, "auto_increment")
+        case tok.is("primary"):
+            if r.peekIs("key") {
+                r.next()
+            }
+            t.indexes = append(t.indexes, Index{Name: "PRIMARY", Unique: true, Primary: true, Columns: []string{column.Name}})
+        case tok.is("key"):
+            t.indexes = append(t.indexes, Index{Name: "PRIMARY", Unique: true, Primary: true, Columns: []string{column.Name}})
+        case tok.is("unique"):
+            if r.peekIs("key") {
+                r.next()
+            }
+            t.indexes = append(t.indexes, Index{Name: column.Name, Unique: true, Columns: []string{column.Name}})
+        case tok.is("comment"):
+            column.Comment = r.next().value
+        case tok.is("default"):
+            column.Default = r.defaultValue()
+        case tok.is("on"):
+            if r.peekIs("update") {
+                r.next()
+                if value := r.defaultValue(); nil != value {
+                    extra = append(extra, "on update "+*value)
+                }
+            }
+        case tok.is("as"):
+            r.group()
+            generated := "VIRTUAL GENERATED"
+            if r.peekIs("stored") || r.peekIs("persistent") {
+                generated = "STORED GENERATED"
+            }
+            extra = append(extra, generated)
+        case tok.is("check"), tok.is("("):
+            if tok.is("(") {
+                r.back()
+            }
+            r.group()
+        }
+    }
+    column.Extra = strings.Join(extra, " ")
+    t.columns = append(t.columns, column)
+    return nil
+}
+
+// defaultValue 读取默认值, 可能是字面量、函数调用或括号表达式, NULL 返回 nil
+func (r *tokenReader) defaultValue() *string {
+    var value string
+    switch {
+    case r.peekIs("("):
+        value = joinTokens(r.group())
+    case r.peekIs("null"):
+        r.next()
+        return nil
+    case r.peekIs("-"), r.peekIs("+"):
+        value = r.next().value + r.next().value
+    case r.peekIs("b") || r.peekIs("x"): // b'0101' 与 x'0F', 与 information_schema 一致输出为 b'0101' 与 0x0F
+        prefix := strings.ToLower(r.next().value)
+        if !r.eof() && tokenString == r.tokens[r.pos].kind {
+            if "b" == prefix {
+                value = "b'" + r.next().value + "'"
+            } else {
+                value = "0x" + strings.ToUpper(r.next().value)
+            }
+        } else {
+            value = prefix
+        }
+    default:
+        value = r.next().value
+        if r.peekIs("(") { // CURRENT_TIMESTAMP(3)
+            value += "(" + joinTokens(r.group()) + ")"
+        }
+    }
+    for r.peekIs(".") { // 小数
+        r.next()
+        value += "." + r.next().value
+    }
+    return &value
+}
+
+// mysqlTypeSize 按 information_schema 的规则计算长度、精度和小数位
+func mysqlTypeSize(dataType string, args []token) (maxLength, precision, scale int64) {
+    var nums []int64
+    for _, v := range args {
+        if tokenNumber == v.kind {
+            n, _ := strconv.ParseInt(v.value, 10, 64)
+            nums = append(nums, n)
+        }
+    }
+    switch dataType {
+    case "char", "binary":
+        maxLength = 1
+        if 0 < len(nums) {
+            maxLength = nums[0]
+        }
+    case "varchar", "varbinary":
+        if 0 < len(nums) {
+            maxLength = nums[0]
+        }
+    case "tinytext", "tinyblob":
+        maxLength = 255
+    case "text", "blob":
+        maxLength = 65535
+    case "mediumtext", "mediumblob":
+        maxLength = 16777215
+    case "longtext", "longblob":
+        maxLength = 4294967295
+    case "tinyint":
+        precision = 3
+    case "smallint":
+        precision = 5
+    case "mediumint":
+        precision = 7
+    case "int", "integer":
+        precision = 10
+    case "bigint":
+        precision = 19
+    case "decimal", "numeric":
+        precision = 10
+        if 0 < len(nums) {
+            precision = nums[0]
+        }
+        if 1 < len(nums) {
+            scale = nums[1]
+        }
+    }
+    return
+}
+
+// joinTokens 将 token 还原为 SQL 文本
+func joinTokens(tokens []token) string {
+    var sb strings.Builder
+    for i, v := range tokens {
+        word := tokenSymbol != v.kind
+        if 0 < i && word && tokenSymbol != tokens[i-1].kind {
+            sb.WriteString(" ")
+        }
+        switch v.kind {
+        case tokenString:
+            sb.WriteString("'" + strings.ReplaceAll(v.value, "'", "''") + "'")
+        case tokenQuoted:
+            sb.WriteString("`" + v.value + "`")
+        default:
+            sb.WriteString(v.value)
+        }
+    }
+    return sb.String()
+}
+
+type tokenReader struct {
+    tokens []token
+    pos    int
+}
+
+func (r *tokenReader) eof() bool {
+    return r.pos >= len(r.tokens)
+}
+
+func (r *tokenReader) next() token {
+    if r.eof() {
+        r.pos++
+        return token{}
+    }
+    r.pos++
+    return r.tokens[r.pos-1]
+}
+
+func (r *tokenReader) back() {
+    r.pos--
+}
+
+func (r *tokenReader) peekIs(s string) bool {
+    return !r.eof() && r.tokens[r.pos].is(s)
+}
+
+// qualifiedName 读取 db.table 形式的名字, 只返回最后一段
+func (r *tokenReader) qualifiedName() string {
+    name := r.next().value
+    for r.peekIs(".") {
+        r.next()
+        name = r.next().value
+    }
+    return name
+}
+
+// group 读取到当前层级的逗号或右括号为止; 若当前为左括号, 则读取整个括号内容(不含括号)
+func (r *tokenReader) group() []token {
+    start := r.pos
+    if r.peekIs("(") {
+        r.next()
+        depth := 1
+        for !r.eof() {
+            tok := r.next()
+            if tok.is("(") {
+                depth++
+            } else if tok.is(")") {
+                depth--
+                if 0 == depth {
+                    return r.tokens[start+1 : r.pos-1]
+                }
+            }
+        }
+        return r.tokens[start+1:]
+    }
+    depth := 0
+    for !r.eof() {
+        tok := r.tokens[r.pos]
+        if tok.is("(") {
+            depth++
+        } else if tok.is(")") {
+            if 0 == depth {
+                break
+            }
+            depth--
+        } else if tok.is(",") && 0 == depth {
+            break
+        }
+        r.pos++
+    }
+    return r.tokens[start:r.pos]
+}
+
+// indexColumns 读取 (a, b(10) desc) 形式的索引列
+func (r *tokenReader) indexColumns() []string {
+    for !r.eof() && !r.peekIs("(") { // 跳过 USING BTREE 等
+        r.next()
+    }
+    inner := &tokenReader{tokens: r.group()}
+    var columns []string
+    for !inner.eof() {
+        part := inner.group()
+        if 0 < len(part) && (tokenIdent == part[0].kind || tokenQuoted == part[0].kind) {
+            columns = append(columns, part[0].value)
+        }
+        inner.next() // ,
+    }
+    return columns
+}
+
+// splitStatements 按分号切分语句
+func splitStatements(tokens []token) [][]token {
+    var stmts [][]token
+    start := 0
+    for i, v := range tokens {
+        if v.is(";") {
+            if start < i {
+                stmts = append(stmts, tokens[start:i])
+            }
+            start = i + 1
+        }
+    }
+    if start < len(tokens) {
+        stmts = append(stmts, tokens[start:])
+    }
+    return stmts
+}
+
+// tokenize 词法分析, 忽略注释(包含 /*! ... */ 形式的条件注释)
+func tokenize(s string) ([]token, error) {
+    var tokens []token
+    src := []rune(s)
+    for i := 0; i < len(src); {
+        c := src[i]
+        switch {
+        case ' ' == c || '\t' == c || '\n' == c || '\r' == c:
+            i++
+        case '#' == c || ('-' == c && i+1 < len(src) && '-' == src[i+1]):
+            for i < len(src) && '\n' != src[i] {
+                i++
+            }
+        case '/' == c && i+1 < len(src) && '*' == src[i+1]:
+            j := i + 2
+            for j+1 < len(src) && !('*' == src[j] && '/' == src[j+1]) {
+                j++
+            }
+            if j+1 >= len(src) {
+                return nil, errors.New("unterminated comment in ddl")
+            }
+            i = j + 2
+        case '`' == c || '"' == c || '\'' == c:
+            var sb strings.Builder
+            j := i + 1
+            for ; j < len(src); j++ {
+                if '\\' == src[j] && '\'' == c && j+1 < len(src) {
+                    j++
+                    sb.WriteRune(unescape(src[j]))
+                    continue
+                }
+                if src[j] == c {
+                    if j+1 < len(src) && src[j+1] == c { // 连续两个引号表示引号本身
+                        sb.WriteRune(c)
+                        j++
+                        continue
+                    }
+                    break
+                }
+                sb.WriteRune(src[j])
+            }
+            if j >= len(src) {
+                return nil, errors.New("unterminated quoted string in ddl")
+            }
+            kind := tokenQuoted
+            if '\'' == c {
+                kind = tokenString
+            }
+            tokens = append(tokens, token{kind: kind, value: sb.String()})
+            i = j + 1
+        case isIdentRune(c):
+            j := i
+            for j < len(src) && isIdentRune(src[j]) {
+                j++
+            }
+            kind := tokenIdent
+            if '0' <= c && '9' >= c {
+                kind = tokenNumber
+            }
+            tokens = append(tokens, token{kind: kind, value: string(src[i:j])})
+            i = j
+        default:
+            tokens = append(tokens, token{kind: tokenSymbol, value: string(c)})
+            i++
+        }
+    }
+    return tokens, nil
+}
+
+func isIdentRune(c rune) bool {
+    return '_' == c || '$' == c || ('a' <= c && 'z' >= c) || ('A' <= c && 'Z' >= c) || ('0' <= c && '9' >= c) || 0x80 <= c
+}
+
+func unescape(c rune) rune {
+    switch c {
+    case 'n':
+        return '\n'
+    case 't':
+        return '\t'
+    case 'r':
+        return '\r'
+    case '0':
+        return 0
+    }
+    return c
+}
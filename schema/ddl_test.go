@@ -0,0 +1,315 @@
+package schema
+
+import (
+    "fmt"
+    "reflect"
+    "strings"
+    "testing"
+)
+
+// describe 将表结构输出为便于比较的文本, 每列一行: 名字 类型 KEY NULL 默认值 EXTRA 注释
+func describe(t *testing.T, p Provider, table string) []string {
+    columns, err := p.Columns(table)
+    if nil != err {
+        t.Fatal(err)
+    }
+    var lines []string
+    for i, c := range columns {
+        if i+1 != c.Position {
+            t.Errorf("column %s.%s: position = %d, want %d", table, c.Name, c.Position, i+1)
+        }
+        def := "-"
+        if nil != c.Default {
+            def = *c.Default
+        }
+        null := "NOT NULL"
+        if c.Nullable {
+            null = "NULL"
+        }
+        lines = append(lines, fmt.Sprintf("%s|%s|%s|%s|%s|%s|%s", c.Name, c.ColumnType, c.Key, null, def, c.Extra, c.Comment))
+    }
+    return lines
+}
+
+func indexNames(t *testing.T, p Provider, table string) []string {
+    indexes, err := p.Indexes(table)
+    if nil != err {
+        t.Fatal(err)
+    }
+    var names []string
+    for _, v := range indexes {
+        name := v.Name + "(" + strings.Join(v.Columns, ",") + ")"
+        if v.Unique && !v.Primary {
+            name = "unique " + name
+        }
+        names = append(names, name)
+    }
+    return names
+}
+
+func TestDdlProvider(t *testing.T) {
+    tests := []struct {
+        name    string
+        ddl     string
+        table   string
+        comment string
+        columns []string
+        indexes []string
+    }{
+        {
+            name: "create table",
+            ddl: "CREATE TABLE IF NOT EXISTS `shop`.`bt_item` (\n" +
+                "  `id` bigint(20) unsigned NOT NULL AUTO_INCREMENT COMMENT 'id',\n" +
+                "  `name` varchar(64) NOT NULL DEFAULT '' COMMENT 'it''s name',\n" +
+                "  `price` decimal(10,2) DEFAULT NULL,\n" +
+                "  `status` enum('on','off') NOT NULL DEFAULT 'on',\n" +
+                "  `updated_at` datetime(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3),\n" +
+                "  PRIMARY KEY (`id`),\n" +
+                "  UNIQUE KEY `uk_name` (`name`),\n" +
+                "  KEY `idx_status` (`status`, `updated_at`) USING BTREE\n" +
+                ") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='item';",
+            table:   "bt_item",
+            comment: "item",
+            columns: []string{
+                "id|bigint(20) unsigned|PRI|NOT NULL|-|auto_increment|id",
+                "name|varchar(64)|UNI|NOT NULL|||it's name",
+                "price|decimal(10,2)||NULL|-||",
+                "status|enum('on','off')|MUL|NOT NULL|on||",
+                "updated_at|datetime(3)||NOT NULL|CURRENT_TIMESTAMP(3)|on update CURRENT_TIMESTAMP(3)|",
+            },
+            indexes: []string{"PRIMARY(id)", "unique uk_name(name)", "idx_status(status,updated_at)"},
+        },
+        {
+            name: "comments and quoting",
+            ddl: "-- leading comment\n" +
+                "# hash comment\n" +
+                "/* block; comment */\n" +
+                "/*!40101 SET NAMES utf8 */;\n" +
+                "create table \"quoted\" (\n" +
+                "  \"a b\" int primary key, -- trailing comment\n" +
+                "  note varchar(10) comment 'semi; colon \\'q\\''\n" +
+                ")",
+            table: "quoted",
+            columns: []string{
+                "a b|int|PRI|NOT NULL|-||",
+                "note|varchar(10)||NULL|-||semi; colon 'q'",
+            },
+            indexes: []string{"PRIMARY(a b)"},
+        },
+        {
+            name: "nested parentheses and generated columns",
+            ddl: "create table calc (\n" +
+                "  id int not null,\n" +
+                "  total decimal(12,4) default (0.5),\n" +
+                "  doubled int as ((id * 2)) stored,\n" +
+                "  created date default (curdate()),\n" +
+                "  check (id > 0),\n" +
+                "  key (created)\n" +
+                ")",
+            table: "calc",
+            columns: []string{
+                "id|int||NOT NULL|-||",
+                "total|decimal(12,4)||NULL|0.5||",
+                "doubled|int||NULL|-|STORED GENERATED|",
+                "created|date|MUL|NULL|curdate()||",
+            },
+            indexes: []string{"created(created)"},
+        },
+        {
+            name: "constraint forms",
+            ddl: "create table parent (id int primary key);\n" +
+                "create table child (\n" +
+                "  id int,\n" +
+                "  parent_id int,\n" +
+                "  code char(4),\n" +
+                "  constraint pk_child primary key (id),\n" +
+                "  constraint uk_code unique (code),\n" +
+                "  constraint fk_parent foreign key (parent_id) references parent (id) on delete cascade\n" +
+                ")",
+            table: "child",
+            columns: []string{
+                "id|int|PRI|NOT NULL|-||",
+                "parent_id|int|MUL|NULL|-||",
+                "code|char(4)|UNI|NULL|-||",
+            },
+            indexes: []string{"PRIMARY(id)", "unique uk_code(code)", "fk_parent(parent_id)"},
+        },
+        {
+            name: "alter table",
+            ddl: "create table a (id int primary key, name varchar(10), age int, key idx_name (name));\n" +
+                "alter table a add column email varchar(20) not null after id, modify name varchar(32) comment 'n',\n" +
+                "  change age years int first, drop index idx_name, add unique key uk_email (email);\n" +
+                "alter table a rename column email to mail, comment = 'renamed', engine = InnoDB;\n" +
+                "alter table a alter column years set default 3, add (x int, y int), drop column y;\n" +
+                "rename table a to b;",
+            table:   "b",
+            comment: "renamed",
+            columns: []string{
+                "years|int||NULL|3||",
+                "id|int|PRI|NOT NULL|-||",
+                "mail|varchar(20)|UNI|NOT NULL|-||",
+                "name|varchar(32)||NULL|-||n",
+                "x|int||NULL|-||",
+            },
+            indexes: []string{"PRIMARY(id)", "unique uk_email(mail)"},
+        },
+        {
+            name: "drop and recreate",
+            ddl: "create table t (id int);\n" +
+                "create index idx_id on t (id);\n" +
+                "drop index idx_id on t;\n" +
+                "drop table if exists t;\n" +
+                "create table t (code varchar(8) primary key);",
+            table:   "t",
+            columns: []string{"code|varchar(8)|PRI|NOT NULL|-||"},
+            indexes: []string{"PRIMARY(code)"},
+        },
+        {
+            name: "create like",
+            ddl: "create table parent (id int primary key);\n" +
+                "create table s (id int auto_increment primary key, pid int, name varchar(8) comment 'n', unique key uk_name (name),\n" +
+                "  foreign key (pid) references parent (id)) comment 'source';\n" +
+                "create table t like s;\n" +
+                "alter table t rename column name to title;",
+            table:   "t",
+            comment: "source",
+            columns: []string{
+                "id|int|PRI|NOT NULL|-|auto_increment|",
+                "pid|int|MUL|NULL|-||",
+                "title|varchar(8)|UNI|NULL|-||n",
+            },
+            indexes: []string{"PRIMARY(id)", "unique uk_name(title)", "s_ibfk_1(pid)"},
+        },
+        {
+            name: "create parenthesized like",
+            ddl: "create table s (id int primary key, key idx_id (id));\n" +
+                "create table if not exists `db`.`t` (like `db`.`s`);\n" +
+                "alter table s drop index idx_id;",
+            table:   "t",
+            columns: []string{"id|int|PRI|NOT NULL|-||"},
+            indexes: []string{"PRIMARY(id)", "idx_id(id)"},
+        },
+        {
+            name: "bit and hex literals",
+            ddl: "create table flags (\n" +
+                "  a bit(4) not null default b'0101',\n" +
+                "  b binary(2) default X'0f1A',\n" +
+                "  c bit(1) default 0b1,\n" +
+                "  d varbinary(4) default 0x41\n" +
+                ");\n" +
+                "alter table flags alter column c set default B'1';",
+            table: "flags",
+            columns: []string{
+                "a|bit(4)||NOT NULL|b'0101'||",
+                "b|binary(2)||NULL|0x0F1A||",
+                "c|bit(1)||NULL|b'1'||",
+                "d|varbinary(4)||NULL|0x41||",
+            },
+        },
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            p, err := NewDdlProvider(tt.ddl)
+            if nil != err {
+                t.Fatal(err)
+            }
+            tables, err := p.Tables([]string{tt.table})
+            if nil != err {
+                t.Fatal(err)
+            }
+            if 1 != len(tables) {
+                t.Fatalf("tables = %v, want %s", tables, tt.table)
+            }
+            if tables[0].Comment != tt.comment {
+                t.Errorf("comment = %q, want %q", tables[0].Comment, tt.comment)
+            }
+            if got := describe(t, p, tt.table); !reflect.DeepEqual(got, tt.columns) {
+                t.Errorf("columns:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(tt.columns, "\n"))
+            }
+            if got := indexNames(t, p, tt.table); !reflect.DeepEqual(got, tt.indexes) {
+                t.Errorf("indexes = %v, want %v", got, tt.indexes)
+            }
+        })
+    }
+}
+
+func TestDdlProviderForeignKeys(t *testing.T) {
+    p, err := NewDdlProvider("create table parent (id int primary key);\n" +
+        "create table child (id int primary key, parent_id int, foreign key (parent_id) references parent (id) on update set null);\n" +
+        "rename table parent to owner;")
+    if nil != err {
+        t.Fatal(err)
+    }
+    fks, err := p.ForeignKeys("owner")
+    if nil != err {
+        t.Fatal(err)
+    }
+    want := []ForeignKey{{Name: "child_ibfk_1", Table: "child", Columns: []string{"parent_id"}, RefTable: "owner",
+        RefColumns: []string{"id"}, OnUpdate: "SET NULL", OnDelete: "NO ACTION"}}
+    if !reflect.DeepEqual(fks, want) {
+        t.Errorf("foreign keys = %+v, want %+v", fks, want)
+    }
+
+    p, err = NewDdlProvider("create table parent (id int primary key);\n" +
+        "create table child (id int, parent_id int, constraint fk_p foreign key (parent_id) references parent (id));\n" +
+        "alter table child drop foreign key fk_p;")
+    if nil != err {
+        t.Fatal(err)
+    }
+    if fks, _ := p.ForeignKeys("child"); 0 != len(fks) {
+        t.Errorf("foreign keys = %+v, want none", fks)
+    }
+}
+
+func TestDdlProviderTemporary(t *testing.T) {
+    p, err := NewDdlProvider("create temporary table tmp (id int, key idx_id (id));\n" +
+        "alter table tmp add column x int, partition by hash (id);\n" +
+        "drop index idx_id on tmp;\n" +
+        "create table t (id int);")
+    if nil != err {
+        t.Fatal(err)
+    }
+    tables, err := p.Tables(nil)
+    if nil != err {
+        t.Fatal(err)
+    }
+    if want := []Table{{Name: "t"}}; !reflect.DeepEqual(tables, want) {
+        t.Errorf("tables = %+v, want %+v", tables, want)
+    }
+}
+
+func TestDdlProviderMalformed(t *testing.T) {
+    tests := []struct {
+        name string
+        ddl  string
+        err  string
+    }{
+        {"unterminated comment", "create table t (id int) /* comment", "unterminated comment"},
+        {"unterminated string", "create table t (id int comment 'x)", "unterminated quoted string"},
+        {"missing table name", "create table if not exists", "table name not found"},
+        {"missing column list", "create table t id int", "invalid create table statement of t"},
+        {"create like unknown table", "create table t like s", "table s not found"},
+        {"create like unclosed", "create table s (id int); create table t (like s", "invalid create table statement of t"},
+        {"invalid column", "create table t (id 1)", "invalid definition of column t.id"},
+        {"alter add without definition", "create table t (id int); alter table t add column;", "definition after ADD is missing"},
+        {"alter add nothing", "create table t (id int); alter table t add;", "definition after ADD is missing"},
+        {"alter modify without definition", "create table t (id int); alter table t modify;", "definition after MODIFY is missing"},
+        {"alter drop unknown column", "create table t (id int); alter table t drop column x;", "column x not found"},
+        {"alter drop unknown index", "create table t (id int); alter table t drop index x;", "index x not found"},
+        {"alter unsupported clause", "create table t (id int); alter table t partition by hash (id);", "is not supported"},
+        {"rename unknown table", "rename table x to y;", "table x not found"},
+        {"alter unknown table", "create table t (id int); alter table x add column y int;", "table x not found"},
+        {"drop index of unknown table", "create table t (id int); drop index i on x;", "table x not found"},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            _, err := NewDdlProvider(tt.ddl)
+            if nil == err {
+                t.Fatalf("expected error containing %q", tt.err)
+            }
+            if !strings.Contains(err.Error(), tt.err) {
+                t.Errorf("error = %q, want it to contain %q", err.Error(), tt.err)
+            }
+        })
+    }
+}
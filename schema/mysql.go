@@ -0,0 +1,126 @@
+package schema
+
+import (
+    "database/sql"
+    "strings"
+)
+
+type mysqlProvider struct {
+    db       *sql.DB
+    database string
+}
+
+// NewMysqlProvider db 需连接到 information_schema
+func NewMysqlProvider(db *sql.DB, database string) Provider {
+    return &mysqlProvider{db: db, database: database}
+}
+
+func (p *mysqlProvider) Tables(names []string) ([]Table, error) {
+    var rows *sql.Rows
+    var err error
+    if 0 < len(names) {
+        params := []interface{}{p.database}
+        for _, v := range names {
+            params = append(params, v)
+        }
+        rows, err = p.db.Query("select TABLE_NAME as TableName, TABLE_COMMENT as `Comment` from TABLES where TABLE_SCHEMA = ? and TABLE_NAME in (?"+strings.Repeat(",?", len(names)-1)+")", params...)
+    } else {
+        rows, err = p.db.Query("select TABLE_NAME as TableName, TABLE_COMMENT as `Comment` from TABLES where TABLE_SCHEMA = ?", p.database)
+    }
+    if nil != err {
+        return nil, err
+    }
+    defer rows.Close()
+    var tables []Table
+    for rows.Next() {
+        var table Table
+        if err := rows.Scan(&table.Name, &table.Comment); nil != err {
+            return nil, err
+        }
+        tables = append(tables, table)
+    }
+    return tables, rows.Err()
+}
+
+func (p *mysqlProvider) Columns(table string) ([]Column, error) {
+    rows, err := p.db.Query("select `COLUMN_NAME` as Field, `DATA_TYPE` as DataType, `COLUMN_TYPE`, `COLUMN_KEY` as `Index`, `COLUMN_COMMENT` as Comment, "+
+        "`IS_NULLABLE`, `COLUMN_DEFAULT`, `EXTRA`, `CHARACTER_MAXIMUM_LENGTH`, `NUMERIC_PRECISION`, `NUMERIC_SCALE`, `ORDINAL_POSITION` "+
+        "from `COLUMNS` where TABLE_SCHEMA = ? AND TABLE_NAME = ? order by ORDINAL_POSITION", p.database, table)
+    if nil != err {
+        return nil, err
+    }
+    defer rows.Close()
+    var columns []Column
+    for rows.Next() {
+        var column Column
+        var nullable string
+        var def sql.NullString
+        var maxLength, precision, scale sql.NullInt64
+        if err := rows.Scan(&column.Name, &column.DataType, &column.ColumnType, &column.Key, &column.Comment,
+            &nullable, &def, &column.Extra, &maxLength, &precision, &scale, &column.Position); nil != err {
+            return nil, err
+        }
+        column.Nullable = "YES" == nullable
+        if def.Valid {
+            column.Default = &def.String
+        }
+        column.MaxLength = maxLength.Int64
+        column.Precision = precision.Int64
+        column.Scale = scale.Int64
+        columns = append(columns, column)
+    }
+    return columns, rows.Err()
+}
+
+func (p *mysqlProvider) Indexes(table string) ([]Index, error) {
+    rows, err := p.db.Query("select `INDEX_NAME`, `NON_UNIQUE`, `COLUMN_NAME` from `STATISTICS` where TABLE_SCHEMA = ? AND TABLE_NAME = ? order by INDEX_NAME, SEQ_IN_INDEX", p.database, table)
+    if nil != err {
+        return nil, err
+    }
+    defer rows.Close()
+    var indexes []Index
+    for rows.Next() {
+        var name, columnName string
+        var nonUnique int
+        if err := rows.Scan(&name, &nonUnique, &columnName); nil != err {
+            return nil, err
+        }
+        if 0 == len(indexes) || indexes[len(indexes)-1].Name != name {
+            indexes = append(indexes, Index{Name: name, Unique: 0 == nonUnique, Primary: "PRIMARY" == name})
+        }
+        indexes[len(indexes)-1].Columns = append(indexes[len(indexes)-1].Columns, columnName)
+    }
+    return indexes, rows.Err()
+}
+
+func (p *mysqlProvider) PrimaryKeys(table string) ([]string, error) {
+    indexes, err := p.Indexes(table)
+    if nil != err {
+        return nil, err
+    }
+    return primaryKeys(indexes), nil
+}
+
+func (p *mysqlProvider) ForeignKeys(table string) ([]ForeignKey, error) {
+    rows, err := p.db.Query("select k.`CONSTRAINT_NAME`, k.`TABLE_NAME`, k.`COLUMN_NAME`, k.`REFERENCED_TABLE_NAME`, k.`REFERENCED_COLUMN_NAME`, r.`UPDATE_RULE`, r.`DELETE_RULE` "+
+        "from `KEY_COLUMN_USAGE` k join `REFERENTIAL_CONSTRAINTS` r on r.CONSTRAINT_SCHEMA = k.CONSTRAINT_SCHEMA and r.TABLE_NAME = k.TABLE_NAME and r.CONSTRAINT_NAME = k.CONSTRAINT_NAME "+
+        "where k.TABLE_SCHEMA = ? and k.REFERENCED_TABLE_SCHEMA = k.TABLE_SCHEMA and (k.TABLE_NAME = ? or k.REFERENCED_TABLE_NAME = ?) "+
+        "order by k.TABLE_NAME, k.CONSTRAINT_NAME, k.ORDINAL_POSITION", p.database, table, table)
+    if nil != err {
+        return nil, err
+    }
+    defer rows.Close()
+    var fks []ForeignKey
+    for rows.Next() {
+        var name, tableName, columnName, refTable, refColumn, onUpdate, onDelete string
+        if err := rows.Scan(&name, &tableName, &columnName, &refTable, &refColumn, &onUpdate, &onDelete); nil != err {
+            return nil, err
+        }
+        fks = appendForeignKey(fks, name, tableName, columnName, refTable, refColumn, onUpdate, onDelete)
+    }
+    return fks, rows.Err()
+}
+
+func (p *mysqlProvider) Close() error {
+    return p.db.Close()
+}
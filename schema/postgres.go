@@ -0,0 +1,178 @@
+package schema
+
+import (
+    "database/sql"
+    "github.com/lib/pq"
+    "strings"
+)
+
+type postgresProvider struct {
+    db     *sql.DB
+    schema string
+}
+
+// NewPostgresProvider schema 为 PostgreSQL 中的 schema 名, 一般为 public
+func NewPostgresProvider(db *sql.DB, schema string) Provider {
+    if "" == schema {
+        schema = "public"
+    }
+    return &postgresProvider{db: db, schema: schema}
+}
+
+func (p *postgresProvider) Tables(names []string) ([]Table, error) {
+    query := "select c.relname, coalesce(obj_description(c.oid, 'pg_class'), '') from pg_class c " +
+        "join pg_namespace n on n.oid = c.relnamespace where n.nspname = $1 and c.relkind in ('r', 'p')"
+    params := []interface{}{p.schema}
+    if 0 < len(names) {
+        query += " and c.relname = any($2)"
+        params = append(params, pq.Array(names))
+    }
+    rows, err := p.db.Query(query+" order by c.relname", params...)
+    if nil != err {
+        return nil, err
+    }
+    defer rows.Close()
+    var tables []Table
+    for rows.Next() {
+        var table Table
+        if err := rows.Scan(&table.Name, &table.Comment); nil != err {
+            return nil, err
+        }
+        tables = append(tables, table)
+    }
+    return tables, rows.Err()
+}
+
+func (p *postgresProvider) Columns(table string) ([]Column, error) {
+    indexes, err := p.Indexes(table)
+    if nil != err {
+        return nil, err
+    }
+    rows, err := p.db.Query("select c.column_name, c.udt_name, format_type(a.atttypid, a.atttypmod), coalesce(col_description(a.attrelid, a.attnum), ''), "+
+        "c.is_nullable, c.column_default, c.is_identity, c.is_generated, c.character_maximum_length, c.numeric_precision, c.numeric_scale, c.ordinal_position "+
+        "from information_schema.columns c "+
+        "join pg_attribute a on a.attrelid = format('%I.%I', c.table_schema, c.table_name)::regclass and a.attname = c.column_name "+
+        "where c.table_schema = $1 and c.table_name = $2 order by c.ordinal_position", p.schema, table)
+    if nil != err {
+        return nil, err
+    }
+    defer rows.Close()
+    var columns []Column
+    for rows.Next() {
+        var column Column
+        var nullable, identity, generated string
+        var def sql.NullString
+        var maxLength, precision, scale sql.NullInt64
+        if err := rows.Scan(&column.Name, &column.DataType, &column.ColumnType, &column.Comment,
+            &nullable, &def, &identity, &generated, &maxLength, &precision, &scale, &column.Position); nil != err {
+            return nil, err
+        }
+        column.Nullable = "YES" == nullable
+        if def.Valid {
+            column.Default = &def.String
+        }
+        // serial 类型在 information_schema 中表现为带 nextval 默认值的整型
+        if def.Valid && strings.HasPrefix(def.String, "nextval(") {
+            switch column.DataType {
+            case "int2":
+                column.DataType = "smallserial"
+            case "int4":
+                column.DataType = "serial"
+            case "int8":
+                column.DataType = "bigserial"
+            }
+            column.Extra = "auto_increment"
+        }
+        if "YES" == identity {
+            column.Extra = "auto_increment"
+        }
+        if "ALWAYS" == generated {
+            column.Extra = "STORED GENERATED"
+        }
+        column.MaxLength = maxLength.Int64
+        column.Precision = precision.Int64
+        column.Scale = scale.Int64
+        column.Key = columnKey(column.Name, indexes)
+        columns = append(columns, column)
+    }
+    return columns, rows.Err()
+}
+
+func (p *postgresProvider) Indexes(table string) ([]Index, error) {
+    rows, err := p.db.Query("select i.relname, ix.indisunique, ix.indisprimary, a.attname from pg_index ix "+
+        "join pg_class t on t.oid = ix.indrelid "+
+        "join pg_class i on i.oid = ix.indexrelid "+
+        "join pg_namespace n on n.oid = t.relnamespace "+
+        "join lateral unnest(ix.indkey) with ordinality as k(attnum, ord) on true "+
+        "join pg_attribute a on a.attrelid = t.oid and a.attnum = k.attnum "+
+        "where n.nspname = $1 and t.relname = $2 order by i.relname, k.ord", p.schema, table)
+    if nil != err {
+        return nil, err
+    }
+    defer rows.Close()
+    var indexes []Index
+    for rows.Next() {
+        var name, columnName string
+        var unique, primary bool
+        if err := rows.Scan(&name, &unique, &primary, &columnName); nil != err {
+            return nil, err
+        }
+        if 0 == len(indexes) || indexes[len(indexes)-1].Name != name {
+            indexes = append(indexes, Index{Name: name, Unique: unique, Primary: primary})
+        }
+        indexes[len(indexes)-1].Columns = append(indexes[len(indexes)-1].Columns, columnName)
+    }
+    return indexes, rows.Err()
+}
+
+func (p *postgresProvider) PrimaryKeys(table string) ([]string, error) {
+    indexes, err := p.Indexes(table)
+    if nil != err {
+        return nil, err
+    }
+    return primaryKeys(indexes), nil
+}
+
+func (p *postgresProvider) ForeignKeys(table string) ([]ForeignKey, error) {
+    rows, err := p.db.Query("select con.conname, cl.relname, a.attname, rcl.relname, ra.attname, con.confupdtype, con.confdeltype from pg_constraint con "+
+        "join pg_class cl on cl.oid = con.conrelid "+
+        "join pg_class rcl on rcl.oid = con.confrelid "+
+        "join pg_namespace n on n.oid = cl.relnamespace "+
+        "join lateral unnest(con.conkey, con.confkey) with ordinality as k(attnum, refattnum, ord) on true "+
+        "join pg_attribute a on a.attrelid = con.conrelid and a.attnum = k.attnum "+
+        "join pg_attribute ra on ra.attrelid = con.confrelid and ra.attnum = k.refattnum "+
+        "where con.contype = 'f' and n.nspname = $1 and (cl.relname = $2 or rcl.relname = $2) "+
+        "order by cl.relname, con.conname, k.ord", p.schema, table)
+    if nil != err {
+        return nil, err
+    }
+    defer rows.Close()
+    var fks []ForeignKey
+    for rows.Next() {
+        var name, tableName, columnName, refTable, refColumn, onUpdate, onDelete string
+        if err := rows.Scan(&name, &tableName, &columnName, &refTable, &refColumn, &onUpdate, &onDelete); nil != err {
+            return nil, err
+        }
+        fks = appendForeignKey(fks, name, tableName, columnName, refTable, refColumn, postgresRule(onUpdate), postgresRule(onDelete))
+    }
+    return fks, rows.Err()
+}
+
+// postgresRule 将 pg_constraint 中的动作代码转为 information_schema 中的写法
+func postgresRule(code string) string {
+    switch code {
+    case "r":
+        return "RESTRICT"
+    case "c":
+        return "CASCADE"
+    case "n":
+        return "SET NULL"
+    case "d":
+        return "SET DEFAULT"
+    }
+    return "NO ACTION"
+}
+
+func (p *postgresProvider) Close() error {
+    return p.db.Close()
+}
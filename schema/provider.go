@@ -0,0 +1,104 @@
+package schema
+
+// Table 表信息
+type Table struct {
+    Name    string `json:"name" yaml:"name"`
+    Comment string `json:"comment" yaml:"comment"`
+}
+
+// Column 列信息, 各字段与 information_schema.COLUMNS 语义一致:
+// Key 为 PRI / UNI / MUL / 空, Extra 可能包含 auto_increment, on update CURRENT_TIMESTAMP, VIRTUAL GENERATED, STORED GENERATED
+type Column struct {
+    Name       string  `json:"name" yaml:"name"`
+    DataType   string  `json:"dataType" yaml:"dataType"`
+    ColumnType string  `json:"columnType" yaml:"columnType"` // 完整类型, 如 int(10) unsigned, enum('a','b')
+    Key        string  `json:"index" yaml:"index"`
+    Comment    string  `json:"comment" yaml:"comment"`
+    Nullable   bool    `json:"nullable" yaml:"nullable"`
+    Default    *string `json:"default" yaml:"default"` // nil 表示没有默认值
+    Extra      string  `json:"extra" yaml:"extra"`
+    MaxLength  int64   `json:"maxLength" yaml:"maxLength"` // 字符类型的最大长度
+    Precision  int64   `json:"precision" yaml:"precision"`
+    Scale      int64   `json:"scale" yaml:"scale"`
+    Position   int     `json:"position" yaml:"position"` // 从 1 开始
+}
+
+// Index 索引信息, Columns 按索引内的顺序排列
+type Index struct {
+    Name    string   `json:"name" yaml:"name"`
+    Unique  bool     `json:"unique" yaml:"unique"`
+    Primary bool     `json:"primary" yaml:"primary"`
+    Columns []string `json:"columns" yaml:"columns"`
+}
+
+// ForeignKey 外键, Columns 与 RefColumns 一一对应
+type ForeignKey struct {
+    Name       string   `json:"name" yaml:"name"`
+    Table      string   `json:"table" yaml:"table"`
+    Columns    []string `json:"columns" yaml:"columns"`
+    RefTable   string   `json:"refTable" yaml:"refTable"`
+    RefColumns []string `json:"refColumns" yaml:"refColumns"`
+    OnUpdate   string   `json:"onUpdate" yaml:"onUpdate"`
+    OnDelete   string   `json:"onDelete" yaml:"onDelete"`
+}
+
+// Provider 表结构来源, 生成器只通过它读取表结构, 不直接依赖具体数据库
+type Provider interface {
+    // Tables 返回指定的表, names 为空时返回全部表
+    Tables(names []string) ([]Table, error)
+    // Columns 返回表的所有列, 按列定义顺序排列
+    Columns(table string) ([]Column, error)
+    // Indexes 返回表的所有索引(包含主键)
+    Indexes(table string) ([]Index, error)
+    // PrimaryKeys 返回主键列, 按主键内的顺序排列
+    PrimaryKeys(table string) ([]string, error)
+    // ForeignKeys 返回与表相关的外键, 包含本表引用其他表的和其他表引用本表的
+    ForeignKeys(table string) ([]ForeignKey, error)
+    Close() error
+}
+
+// columnKey 根据索引推算列的 COLUMN_KEY, 规则与 MySQL 一致: 主键为 PRI, 唯一索引的首列为 UNI, 普通索引的首列为 MUL
+func columnKey(name string, indexes []Index) string {
+    if contains(primaryKeys(indexes), name) {
+        return "PRI"
+    }
+    key := ""
+    for _, v := range indexes {
+        if 0 == len(v.Columns) || v.Columns[0] != name {
+            continue
+        }
+        if v.Unique && 1 == len(v.Columns) {
+            return "UNI"
+        }
+        key = "MUL"
+    }
+    return key
+}
+
+func primaryKeys(indexes []Index) []string {
+    for _, v := range indexes {
+        if v.Primary {
+            return v.Columns
+        }
+    }
+    return nil
+}
+
+func contains(list []string, s string) bool {
+    for _, v := range list {
+        if v == s {
+            return true
+        }
+    }
+    return false
+}
+
+// appendForeignKey 将一行外键列追加到列表中, 同名外键合并
+func appendForeignKey(fks []ForeignKey, name, table, column, refTable, refColumn, onUpdate, onDelete string) []ForeignKey {
+    if 0 == len(fks) || fks[len(fks)-1].Name != name || fks[len(fks)-1].Table != table {
+        fks = append(fks, ForeignKey{Name: name, Table: table, RefTable: refTable, OnUpdate: onUpdate, OnDelete: onDelete})
+    }
+    fks[len(fks)-1].Columns = append(fks[len(fks)-1].Columns, column)
+    fks[len(fks)-1].RefColumns = append(fks[len(fks)-1].RefColumns, refColumn)
+    return fks
+}
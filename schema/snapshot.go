@@ -0,0 +1,135 @@
+package schema
+
+import (
+    "encoding/json"
+    "fmt"
+    "gopkg.in/yaml.v3"
+)
+
+// Snapshot 表结构快照, 可导出为 JSON 或 YAML 文件, 之后不连接数据库即可生成代码
+type Snapshot struct {
+    Driver   string          `json:"driver" yaml:"driver"`
+    Database string          `json:"database" yaml:"database"`
+    Tables   []SnapshotTable `json:"tables" yaml:"tables"`
+}
+
+type SnapshotTable struct {
+    Table   `yaml:",inline"`
+    Columns []SnapshotColumn `json:"columns" yaml:"columns"`
+    Indexes []Index          `json:"indexes" yaml:"indexes"`
+    // 本表引用其他表的外键
+    ForeignKeys []ForeignKey `json:"foreignKeys" yaml:"foreignKeys"`
+}
+
+// SnapshotColumn 除列信息外还记录了生成时计算出的类型, 便于审查表结构变更
+type SnapshotColumn struct {
+    Column   `yaml:",inline"`
+    IsPk     bool   `json:"isPk" yaml:"isPk"`
+    JavaType string `json:"javaType" yaml:"javaType"`
+    JdbcType string `json:"jdbcType" yaml:"jdbcType"`
+}
+
+// Marshal format 为 json 或 yaml
+func (s *Snapshot) Marshal(format string) ([]byte, error) {
+    switch format {
+    case "json":
+        data, err := json.MarshalIndent(s, "", "  ")
+        if nil != err {
+            return nil, err
+        }
+        return append(data, '\n'), nil
+    case "yaml":
+        return yaml.Marshal(s)
+    }
+    return nil, fmt.Errorf("unsupported snapshot format %s", format)
+}
+
+// ParseSnapshot format 为 json 或 yaml
+func ParseSnapshot(data []byte, format string) (*Snapshot, error) {
+    var s Snapshot
+    var err error
+    switch format {
+    case "json":
+        err = json.Unmarshal(data, &s)
+    case "yaml":
+        err = yaml.Unmarshal(data, &s)
+    default:
+        err = fmt.Errorf("unsupported snapshot format %s", format)
+    }
+    if nil != err {
+        return nil, err
+    }
+    return &s, nil
+}
+
+type snapshotProvider struct {
+    snapshot *Snapshot
+}
+
+func NewSnapshotProvider(snapshot *Snapshot) Provider {
+    return &snapshotProvider{snapshot: snapshot}
+}
+
+func (p *snapshotProvider) find(name string) (*SnapshotTable, error) {
+    for i, v := range p.snapshot.Tables {
+        if v.Name == name {
+            return &p.snapshot.Tables[i], nil
+        }
+    }
+    return nil, fmt.Errorf("table %s not found in snapshot", name)
+}
+
+func (p *snapshotProvider) Tables(names []string) ([]Table, error) {
+    var tables []Table
+    for _, v := range p.snapshot.Tables {
+        if 0 < len(names) && !contains(names, v.Name) {
+            continue
+        }
+        tables = append(tables, v.Table)
+    }
+    return tables, nil
+}
+
+func (p *snapshotProvider) Columns(table string) ([]Column, error) {
+    t, err := p.find(table)
+    if nil != err {
+        return nil, err
+    }
+    columns := make([]Column, 0, len(t.Columns))
+    for _, v := range t.Columns {
+        columns = append(columns, v.Column)
+    }
+    return columns, nil
+}
+
+func (p *snapshotProvider) Indexes(table string) ([]Index, error) {
+    t, err := p.find(table)
+    if nil != err {
+        return nil, err
+    }
+    return t.Indexes, nil
+}
+
+func (p *snapshotProvider) PrimaryKeys(table string) ([]string, error) {
+    t, err := p.find(table)
+    if nil != err {
+        return nil, err
+    }
+    return primaryKeys(t.Indexes), nil
+}
+
+func (p *snapshotProvider) ForeignKeys(table string) ([]ForeignKey, error) {
+    var fks []ForeignKey
+    for _, v := range p.snapshot.Tables {
+        for _, fk := range v.ForeignKeys {
+            if fk.Table == table || fk.RefTable == table {
+                fks = append(fks, fk)
+            }
+        }
+    }
+    return fks, nil
+}
+
+func (p *snapshotProvider) Close() error {
+    return nil
+}
@@ -0,0 +1,252 @@
+package schema
+
+import (
+    "database/sql"
+    "fmt"
+    "sort"
+    "strconv"
+    "strings"
+)
+
+type sqliteProvider struct {
+    db *sql.DB
+}
+
+// NewSqliteProvider SQLite 没有注释, 表和列的 Comment 均为空
+func NewSqliteProvider(db *sql.DB) Provider {
+    return &sqliteProvider{db: db}
+}
+
+func (p *sqliteProvider) Tables(names []string) ([]Table, error) {
+    query := "select name from sqlite_master where type = 'table' and name not like 'sqlite_%'"
+    var params []interface{}
+    if 0 < len(names) {
+        query += " and name in (?" + strings.Repeat(",?", len(names)-1) + ")"
+        for _, v := range names {
+            params = append(params, v)
+        }
+    }
+    rows, err := p.db.Query(query+" order by name", params...)
+    if nil != err {
+        return nil, err
+    }
+    defer rows.Close()
+    var tables []Table
+    for rows.Next() {
+        var table Table
+        if err := rows.Scan(&table.Name); nil != err {
+            return nil, err
+        }
+        tables = append(tables, table)
+    }
+    return tables, rows.Err()
+}
+
+func (p *sqliteProvider) Columns(table string) ([]Column, error) {
+    indexes, err := p.Indexes(table)
+    if nil != err {
+        return nil, err
+    }
+    // hidden: 0 普通列, 1 虚拟表的隐藏列, 2 VIRTUAL 生成列, 3 STORED 生成列
+    rows, err := p.db.Query("select cid, name, type, \"notnull\", dflt_value, pk, hidden from pragma_table_xinfo(?) where hidden <> 1 order by cid", table)
+    if nil != err {
+        return nil, err
+    }
+    defer rows.Close()
+    var columns []Column
+    pkCount := 0
+    for rows.Next() {
+        var column Column
+        var notNull, pk, hidden int
+        var def sql.NullString
+        if err := rows.Scan(&column.Position, &column.Name, &column.ColumnType, &notNull, &def, &pk, &hidden); nil != err {
+            return nil, err
+        }
+        column.Position++
+        column.ColumnType = strings.ToLower(strings.TrimSpace(column.ColumnType))
+        column.DataType = sqliteDataType(column.ColumnType)
+        column.MaxLength, column.Precision, column.Scale = sqliteTypeSize(column.DataType, column.ColumnType)
+        column.Nullable = 0 == notNull && 0 == pk
+        if def.Valid {
+            column.Default = &def.String
+        }
+        switch hidden {
+        case 2:
+            column.Extra = "VIRTUAL GENERATED"
+        case 3:
+            column.Extra = "STORED GENERATED"
+        }
+        if 0 < pk {
+            pkCount++
+        }
+        column.Key = columnKey(column.Name, indexes)
+        columns = append(columns, column)
+    }
+    if err := rows.Err(); nil != err {
+        return nil, err
+    }
+    // 单列的 INTEGER PRIMARY KEY 是 rowid 的别名, 插入时自动生成
+    for i, v := range columns {
+        if 1 == pkCount && "PRI" == v.Key && "integer" == v.DataType {
+            columns[i].Extra = "auto_increment"
+        }
+    }
+    return columns, nil
+}
+
+func (p *sqliteProvider) Indexes(table string) ([]Index, error) {
+    rows, err := p.db.Query("select name, \"unique\", origin from pragma_index_list(?)", table)
+    if nil != err {
+        return nil, err
+    }
+    var indexes []Index
+    for rows.Next() {
+        var index Index
+        var origin string
+        if err := rows.Scan(&index.Name, &index.Unique, &origin); nil != err {
+            rows.Close()
+            return nil, err
+        }
+        index.Primary = "pk" == origin
+        indexes = append(indexes, index)
+    }
+    rows.Close()
+    if err := rows.Err(); nil != err {
+        return nil, err
+    }
+    hasPrimary := false
+    for i := range indexes {
+        indexes[i].Columns, err = p.indexColumns(indexes[i].Name)
+        if nil != err {
+            return nil, err
+        }
+        hasPrimary = hasPrimary || indexes[i].Primary
+    }
+    // INTEGER PRIMARY KEY 是 rowid 的别名, 不会出现在 index_list 中
+    if !hasPrimary {
+        pks, err := p.PrimaryKeys(table)
+        if nil != err {
+            return nil, err
+        }
+        if 0 < len(pks) {
+            indexes = append([]Index{{Name: "PRIMARY", Unique: true, Primary: true, Columns: pks}}, indexes...)
+        }
+    }
+    return indexes, nil
+}
+
+func (p *sqliteProvider) indexColumns(index string) ([]string, error) {
+    rows, err := p.db.Query("select name from pragma_index_info(?) order by seqno", index)
+    if nil != err {
+        return nil, err
+    }
+    defer rows.Close()
+    var columns []string
+    for rows.Next() {
+        var name string
+        if err := rows.Scan(&name); nil != err {
+            return nil, err
+        }
+        columns = append(columns, name)
+    }
+    return columns, rows.Err()
+}
+
+func (p *sqliteProvider) PrimaryKeys(table string) ([]string, error) {
+    rows, err := p.db.Query("select name, pk from pragma_table_info(?) where pk > 0", table)
+    if nil != err {
+        return nil, err
+    }
+    defer rows.Close()
+    seq := map[string]int{}
+    var pks []string
+    for rows.Next() {
+        var name string
+        var pk int
+        if err := rows.Scan(&name, &pk); nil != err {
+            return nil, err
+        }
+        seq[name] = pk
+        pks = append(pks, name)
+    }
+    sort.Slice(pks, func(i, j int) bool {
+        return seq[pks[i]] < seq[pks[j]]
+    })
+    return pks, rows.Err()
+}
+
+func (p *sqliteProvider) ForeignKeys(table string) ([]ForeignKey, error) {
+    rows, err := p.db.Query("select m.name, f.id, f.\"table\", f.\"from\", f.\"to\", f.on_update, f.on_delete "+
+        "from sqlite_master m join pragma_foreign_key_list(m.name) f "+
+        "where m.type = 'table' and (m.name = ? or f.\"table\" = ?) order by m.name, f.id, f.seq", table, table)
+    if nil != err {
+        return nil, err
+    }
+    var fks []ForeignKey
+    var missing []int // 未指定引用列的外键, 引用的是主键
+    for rows.Next() {
+        var tableName, refTable, columnName, onUpdate, onDelete string
+        var id int
+        var refColumn sql.NullString
+        if err := rows.Scan(&tableName, &id, &refTable, &columnName, &refColumn, &onUpdate, &onDelete); nil != err {
+            rows.Close()
+            return nil, err
+        }
+        // SQLite 的外键没有名字
+        fks = appendForeignKey(fks, fmt.Sprintf("fk_%s_%d", tableName, id), tableName, columnName, refTable, refColumn.String, onUpdate, onDelete)
+        if !refColumn.Valid && (0 == len(missing) || missing[len(missing)-1] != len(fks)-1) {
+            missing = append(missing, len(fks)-1)
+        }
+    }
+    rows.Close()
+    if err := rows.Err(); nil != err {
+        return nil, err
+    }
+    for _, i := range missing {
+        pks, err := p.PrimaryKeys(fks[i].RefTable)
+        if nil != err {
+            return nil, err
+        }
+        if len(pks) == len(fks[i].Columns) {
+            fks[i].RefColumns = pks
+        }
+    }
+    return fks, nil
+}
+
+func (p *sqliteProvider) Close() error {
+    return p.db.Close()
+}
+
+// sqliteTypeSize 从声明的类型中解析长度, 如 varchar(64), decimal(10,2)
+func sqliteTypeSize(dataType, declType string) (maxLength, precision, scale int64) {
+    start := strings.Index(declType, "(")
+    end := strings.LastIndex(declType, ")")
+    if -1 == start || end < start {
+        return
+    }
+    args := strings.Split(declType[start+1:end], ",")
+    first, _ := strconv.ParseInt(strings.TrimSpace(args[0]), 10, 64)
+    switch dataType {
+    case "char", "varchar", "nchar", "nvarchar", "character", "varying character", "native character":
+        maxLength = first
+    case "decimal", "numeric":
+        precision = first
+        if 1 < len(args) {
+            scale, _ = strconv.ParseInt(strings.TrimSpace(args[1]), 10, 64)
+        }
+    }
+    return
+}
+
+// sqliteDataType 将声明的类型转为小写并去掉长度, 如 VARCHAR(64) -> varchar
+func sqliteDataType(declType string) string {
+    declType = strings.ToLower(strings.TrimSpace(declType))
+    if index := strings.Index(declType, "("); -1 != index {
+        declType = strings.TrimSpace(declType[0:index])
+    }
+    if "" == declType { // 未声明类型的列按 blob 处理
+        return "blob"
+    }
+    return declType
+}
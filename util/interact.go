@@ -34,32 +34,23 @@ var (
             },
         },
     }
-    portQs = []*survey.Question{
-        {
-            Name: "port",
-            Prompt: &survey.Input{
-                Message: "Please provide the port of database, the default is \"3306\" ",
-                Default: "3306",
-            },
-            Validate: func(ans interface{}) error {
-                if err := survey.Required(ans); nil != err {
-                    return err
+    portValidate = func(ans interface{}) error {
+        if err := survey.Required(ans); nil != err {
+            return err
+        }
+        if v, ok := ans.(string); !ok {
+            return errors.New("Input type error")
+        } else {
+            if _v, err := strconv.ParseUint(v, 10, 16); nil != err {
+                return errors.New("Input type error")
+            } else {
+                if 0 >= _v || 65535 < _v {
+                    return errors.New("Port out of bounds")
                 }
-                if v, ok := ans.(string); !ok {
-                    return errors.New("Input type error")
-                } else {
-                    if _v, err := strconv.ParseUint(v, 10, 16); nil != err {
-                        return errors.New("Input type error")
-                    } else {
-                        if 0 >= _v || 65535 < _v {
-                            return errors.New("Port out of bounds")
-                        }
-                    }
+            }
 
-                }
-                return nil
-            },
-        },
+        }
+        return nil
     }
     userQs = []*survey.Question{
         {
@@ -180,16 +171,26 @@ func (interact *Interact) AskDBHost() string {
     return answers.Host
 }
 
-func (Interact *Interact) AskDBPort() uint16 {
+func (Interact *Interact) AskDBPort(defaultPort uint16) uint16 {
     answers := struct {
         Port uint16 `survey:"port"`
     }{}
+    portQs := []*survey.Question{
+        {
+            Name: "port",
+            Prompt: &survey.Input{
+                Message: fmt.Sprintf("Please provide the port of database, the default is \"%d\" ", defaultPort),
+                Default: strconv.Itoa(int(defaultPort)),
+            },
+            Validate: portValidate,
+        },
+    }
     if err := survey.Ask(portQs, &answers); nil != err {
         if terminal.InterruptErr == err {
             Exit()
             os.Exit(0)
         }
-        return 3306
+        return defaultPort
     }
     return answers.Port
 }